@@ -1,22 +1,54 @@
 package ast
 
-// AssignStatement is a "println" statement
+// Kinds of assignment.  Compound assignments
+// (+=, -=, *=, /=) and increments (++, --) are
+// stored as a plain assignment of the whole
+// expression, so "x += 2" is "x = x + 2", with
+// the left operand a TargetValue
+const (
+	PlainAssignment = iota
+	CompoundAssignment
+	IncrementAssignment
+)
+
+// AssignStatement assigns a new value to an existing
+// variable, to an element of a list or map, or to a
+// field of a struct.  The target is an *Identifier, an
+// *IndexExpression or a *FieldExpression
 type AssignStatement struct {
-	SymbolNode     Symbol
+	TargetNode     Expression
 	ExpressionNode Expression
+	Kind           int
+
+	Line int
+	Col  int
 }
 
 // NewAssignStatement ...
-func NewAssignStatement(s Symbol) *AssignStatement {
-	return &AssignStatement{SymbolNode: s}
+func NewAssignStatement(target Expression, kind int, line int, col int) *AssignStatement {
+	return &AssignStatement{TargetNode: target, Kind: kind, Line: line, Col: col}
+}
+
+// OperatorSymbol returns the assignment
+// operator as it was written in source
+func (s *AssignStatement) OperatorSymbol() string {
+	b, isBinary := s.ExpressionNode.(*BinaryExpression)
+	switch {
+	case s.Kind == CompoundAssignment && isBinary:
+		return GetOperatorSymbol(b.Operator) + "="
+	case s.Kind == IncrementAssignment && isBinary:
+		return GetOperatorSymbol(b.Operator) + GetOperatorSymbol(b.Operator)
+	}
+
+	return "="
 }
 
 // AsString return the node as a string
-func (s AssignStatement) AsString(indent string) string {
+func (s AssignStatement) AsString(indent string, types *TypeTable) string {
 	result := indent + "AssignStatement"
 
 	if s.ExpressionNode != nil {
-		result += "\n" + s.ExpressionNode.AsString("  "+indent)
+		result += "\n" + s.ExpressionNode.AsString("  "+indent, types)
 	}
 
 	return result
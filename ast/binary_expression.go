@@ -0,0 +1,105 @@
+package ast
+
+import "fmt"
+
+// BinaryExpression applies an operator
+// to two operands
+type BinaryExpression struct {
+	Operator  int
+	LeftNode  Expression
+	RightNode Expression
+
+	// DataType is the type of the result,
+	// filled in by the checker
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewBinaryExpression ...
+func NewBinaryExpression(operator int, left Expression, right Expression, line int, col int) *BinaryExpression {
+	return &BinaryExpression{Operator: operator, LeftNode: left, RightNode: right, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the result
+func (b *BinaryExpression) GetDataType() int {
+	return b.DataType
+}
+
+// AsString return the node as a string
+func (b *BinaryExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("BinaryExpression '%s' : %s", GetOperatorSymbol(b.Operator), types.GetTypeName(b.DataType))
+	result += "\n" + b.LeftNode.AsString("  "+indent, types)
+	result += "\n" + b.RightNode.AsString("  "+indent, types)
+	return result
+}
+
+// BinaryResultType returns the type of applying the
+// operator to operands of the given types, and false
+// if the operator does not accept those types.
+//
+// Arithmetic works on numbers.  "+" also concatenates
+// strings, and if either side is a string the other may
+// be a number, which is converted as str() would.  Any
+// two values of the same type, or any two numbers, may be
+// tested for equality, while ordering applies only to
+// numbers and strings.
+func BinaryResultType(operator int, left int, right int) (int, bool) {
+	switch operator {
+	case PlusOperator:
+		switch {
+		case IsNumericType(left) && IsNumericType(right):
+			return numericResultType(left, right), true
+		case left == TypeString && (right == TypeString || IsNumericType(right)):
+			return TypeString, true
+		case IsNumericType(left) && right == TypeString:
+			return TypeString, true
+		}
+	case MinusOperator, MultOperator, IntDivOperator, ModuloOperator:
+		if IsNumericType(left) && IsNumericType(right) {
+			return numericResultType(left, right), true
+		}
+	case PowerOperator:
+		// Two ints give an int unless the exponent is
+		// negative, so the kind isn't known until it runs
+		if left == TypeFloat || right == TypeFloat {
+			return TypeFloat, IsNumericType(left) && IsNumericType(right)
+		} else if IsNumericType(left) && IsNumericType(right) {
+			return TypeNumber, true
+		}
+	case DivOperator:
+		if IsNumericType(left) && IsNumericType(right) {
+			return TypeFloat, true
+		}
+	case EqualsOperator, NotEqualsOperator:
+		if (left == right && left != TypeNone) || (IsNumericType(left) && IsNumericType(right)) {
+			return TypeBool, true
+		}
+	case LessThanOperator, LessThanEqualsOperator, GreaterThanOperator, GreaterThanEqualsOperator:
+		if (left == right && left == TypeString) || (IsNumericType(left) && IsNumericType(right)) {
+			return TypeBool, true
+		}
+	case AndOperator, OrOperator:
+		if left == TypeBool && right == TypeBool {
+			return TypeBool, true
+		}
+	}
+
+	return TypeNone, false
+}
+
+// numericResultType returns the type of arithmetic on
+// two numbers.  Anything combined with a float gives a
+// float and two ints give an int, but otherwise the
+// kind of the result isn't known until it runs
+func numericResultType(left int, right int) int {
+	switch {
+	case left == TypeFloat || right == TypeFloat:
+		return TypeFloat
+	case left == TypeInt && right == TypeInt:
+		return TypeInt
+	}
+
+	return TypeNumber
+}
@@ -28,10 +28,10 @@ func (b *Block) RemoveSymbol(name string) {
 }
 
 // AsString return the node as a string
-func (b *Block) AsString(indent string) string {
+func (b *Block) AsString(indent string, types *TypeTable) string {
 	result := indent + "Block"
 	if b.StatementsNode != nil {
-		result += "\n" + b.StatementsNode.AsString(indent+"  ")
+		result += "\n" + b.StatementsNode.AsString(indent+"  ", types)
 	}
 	return result
 }
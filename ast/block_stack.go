@@ -2,7 +2,7 @@ package ast
 
 // BlockStack is a LIFO stack
 // for statement blocks, used by
-// both the parser and executor
+// the parser and checker to track scope
 type BlockStack struct {
 	blocks []*Block
 }
@@ -0,0 +1,61 @@
+package ast
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BoolValue is for any value that
+// can stand in place of a bool
+// Current implementers:
+//    Bool
+type BoolValue interface {
+	GetValue() bool
+	SetValue(value interface{})
+	AsString(indent string, types *TypeTable) string
+	ToString() string
+}
+
+// Bool represents a bool terminal
+type Bool struct {
+	value bool
+}
+
+// NewBool ...
+func NewBool(value bool) *Bool {
+	return &Bool{value: value}
+}
+
+// AsString return the node as a string
+func (b *Bool) AsString(indent string, types *TypeTable) string {
+	return indent + fmt.Sprintf("Bool: '%s'", b.ToString())
+}
+
+// GetDataType returns the type of this bool
+func (b *Bool) GetDataType() int {
+	return TypeBool
+}
+
+// GetValue returns the value of this bool
+func (b *Bool) GetValue() bool {
+	return b.value
+}
+
+// ToString returns the bool formatted as a string
+func (b *Bool) ToString() string {
+	return strconv.FormatBool(b.value)
+}
+
+// SetValue sets the literal value of this bool
+func (b *Bool) SetValue(value interface{}) {
+	switch value.(type) {
+	case *Bool:
+		b.value = value.(*Bool).value
+	case Bool:
+		b.value = value.(Bool).value
+	case bool:
+		b.value = value.(bool)
+	default:
+		panic(fmt.Errorf("Invalid data type for assignment to bool : %T", value))
+	}
+}
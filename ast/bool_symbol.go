@@ -0,0 +1,31 @@
+package ast
+
+// BoolSymbol represents a symbol discovered
+// in parsing
+type BoolSymbol struct {
+	symbolSlot
+
+	Name     string
+	dataType int
+}
+
+// NewBoolSymbol ...
+func NewBoolSymbol(name string) *BoolSymbol {
+	return &BoolSymbol{Name: name, dataType: TypeBool}
+}
+
+// AsString returns a string representation of the
+// symbol
+func (s *BoolSymbol) AsString(indent string, types *TypeTable) string {
+	return formatSymbolAsString(s, indent, types)
+}
+
+// GetName ...
+func (s BoolSymbol) GetName() string {
+	return s.Name
+}
+
+// GetDataType returns the data type identifier
+func (s *BoolSymbol) GetDataType() int {
+	return s.dataType
+}
@@ -0,0 +1,15 @@
+package ast
+
+// BreakStatement exits the innermost loop
+type BreakStatement struct {
+}
+
+// NewBreakStatement ...
+func NewBreakStatement() *BreakStatement {
+	return &BreakStatement{}
+}
+
+// AsString return the node as a string
+func (s BreakStatement) AsString(indent string, types *TypeTable) string {
+	return indent + "BreakStatement"
+}
@@ -0,0 +1,42 @@
+package ast
+
+// CallExpression is a call to a function,
+// either as part of an expression or as
+// a statement on its own.  The checker
+// resolves Name to FunctionNode
+type CallExpression struct {
+	Name          string
+	FunctionNode  *Function
+	ArgumentNodes []Expression
+
+	// DataType is the type of the result, filled
+	// in by the checker.  It is usually the return
+	// type of the function, but for some built-in
+	// functions it depends on the arguments
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewCallExpression ...
+func NewCallExpression(name string, line int, col int) *CallExpression {
+	return &CallExpression{Name: name, ArgumentNodes: []Expression{}, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type the function
+// returns, or TypeNone if it is not resolved
+func (c *CallExpression) GetDataType() int {
+	return c.DataType
+}
+
+// AsString return the node as a string
+func (c *CallExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + "CallExpression : " + c.Name
+
+	for _, a := range c.ArgumentNodes {
+		result += "\n" + a.AsString("  "+indent, types)
+	}
+
+	return result
+}
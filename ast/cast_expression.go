@@ -0,0 +1,30 @@
+package ast
+
+import "fmt"
+
+// CastExpression converts a number to another numeric
+// type.  It is written as int(x), float(x) or number(x),
+// and the checker also adds one wherever an int is
+// widened to a float
+type CastExpression struct {
+	DataType       int
+	ExpressionNode Expression
+
+	Line int
+	Col  int
+}
+
+// NewCastExpression ...
+func NewCastExpression(dataType int, exp Expression, line int, col int) *CastExpression {
+	return &CastExpression{DataType: dataType, ExpressionNode: exp, Line: line, Col: col}
+}
+
+// GetDataType returns the type being converted to
+func (c *CastExpression) GetDataType() int {
+	return c.DataType
+}
+
+// AsString return the node as a string
+func (c *CastExpression) AsString(indent string, types *TypeTable) string {
+	return indent + fmt.Sprintf("CastExpression : %s", types.GetTypeName(c.DataType)) + "\n" + c.ExpressionNode.AsString("  "+indent, types)
+}
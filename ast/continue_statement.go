@@ -0,0 +1,16 @@
+package ast
+
+// ContinueStatement skips to the next
+// iteration of the innermost loop
+type ContinueStatement struct {
+}
+
+// NewContinueStatement ...
+func NewContinueStatement() *ContinueStatement {
+	return &ContinueStatement{}
+}
+
+// AsString return the node as a string
+func (s ContinueStatement) AsString(indent string, types *TypeTable) string {
+	return indent + "ContinueStatement"
+}
@@ -1,14 +1,15 @@
 package ast
 
-// Type of expressions
-const (
-	StringExpressionType = iota
-	NumExpressionType
-	EmptyExpressionType
-)
-
 // Expression interface represents a generic
-// expression
+// expression.  Every expression has a static
+// type, known once it has been parsed
+// Current implementers:
+//    BinaryExpression, UnaryExpression, CastExpression,
+//    CallExpression, Identifier, Number,
+//    String, Bool, ListLiteral, MapLiteral,
+//    StructLiteral, IndexExpression, SliceExpression,
+//    FieldExpression, InterpolatedString, TargetValue
 type Expression interface {
-	AsString(indent string) string
+	GetDataType() int
+	AsString(indent string, types *TypeTable) string
 }
@@ -0,0 +1,37 @@
+package ast
+
+import "fmt"
+
+// FieldExpression reads a field of a struct, as in
+// p.x.  It may also be the target of an assignment,
+// which changes that field
+type FieldExpression struct {
+	StructNode Expression
+	Name       string
+
+	// Index is the position of the field in the
+	// struct's declaration, and DataType its type,
+	// both filled in by the checker
+	Index    int
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewFieldExpression ...
+func NewFieldExpression(structNode Expression, name string, line int, col int) *FieldExpression {
+	return &FieldExpression{StructNode: structNode, Name: name, Index: -1, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the field
+func (f *FieldExpression) GetDataType() int {
+	return f.DataType
+}
+
+// AsString return the node as a string
+func (f *FieldExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("FieldExpression : %s %s", f.Name, types.GetTypeName(f.DataType))
+	result += "\n" + f.StructNode.AsString("  "+indent, types)
+	return result
+}
@@ -0,0 +1,54 @@
+package ast
+
+// ForInStatement is a loop over the entries of a list,
+// map, string or range, as in "for i, v in values".
+// The key variable is optional, so "for v in values"
+// only names the value
+type ForInStatement struct {
+	KeyName        string
+	ValueName      string
+	CollectionNode Expression
+	BlockNode      *Block
+
+	// KeySymbol and ValueSymbol are declared in ScopeNode
+	// by the checker, once the type of the collection is
+	// known.  KeySymbol is nil if there is no key variable
+	KeySymbol   Symbol
+	ValueSymbol Symbol
+
+	// ScopeNode holds the loop variables, and is the
+	// parent of BlockNode.  It is entered afresh for
+	// each entry
+	ScopeNode *Block
+
+	Line int
+	Col  int
+}
+
+// NewForInStatement ...
+func NewForInStatement(scope *Block, line int, col int) *ForInStatement {
+	return &ForInStatement{ScopeNode: scope, Line: line, Col: col}
+}
+
+// AsString return the node as a string
+func (s ForInStatement) AsString(indent string, types *TypeTable) string {
+	result := indent + "ForInStatement"
+
+	if s.KeySymbol != nil {
+		result += "\n  " + indent + "Key\n" + s.KeySymbol.AsString("    "+indent, types)
+	}
+
+	if s.ValueSymbol != nil {
+		result += "\n  " + indent + "Value\n" + s.ValueSymbol.AsString("    "+indent, types)
+	}
+
+	if s.CollectionNode != nil {
+		result += "\n  " + indent + "In\n" + s.CollectionNode.AsString("    "+indent, types)
+	}
+
+	if s.BlockNode != nil {
+		result += "\n" + s.BlockNode.AsString("  "+indent, types)
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package ast
+
+// ForStatement is a "for" loop.  All of
+// InitNode, ConditionNode and StepNode are
+// optional; without a condition the loop runs
+// until a "break" is reached
+type ForStatement struct {
+	InitNode      Statement
+	ConditionNode Expression
+	StepNode      Statement
+	BlockNode     *Block
+
+	// ScopeNode holds the symbols declared
+	// by InitNode, and is the parent of BlockNode
+	ScopeNode *Block
+
+	Line int
+	Col  int
+}
+
+// NewForStatement ...
+func NewForStatement(scope *Block, line int, col int) *ForStatement {
+	return &ForStatement{ScopeNode: scope, Line: line, Col: col}
+}
+
+// AsString return the node as a string
+func (s ForStatement) AsString(indent string, types *TypeTable) string {
+	result := indent + "ForStatement"
+
+	if s.InitNode != nil {
+		result += "\n  " + indent + "Init\n" + s.InitNode.AsString("    "+indent, types)
+	}
+
+	if s.ConditionNode != nil {
+		result += "\n  " + indent + "Condition\n" + s.ConditionNode.AsString("    "+indent, types)
+	}
+
+	if s.StepNode != nil {
+		result += "\n  " + indent + "Step\n" + s.StepNode.AsString("    "+indent, types)
+	}
+
+	if s.BlockNode != nil {
+		result += "\n" + s.BlockNode.AsString("  "+indent, types)
+	}
+
+	return result
+}
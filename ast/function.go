@@ -1,5 +1,7 @@
 package ast
 
+import "fmt"
+
 // Parameter is the definition
 // for a function parameter
 type Parameter struct {
@@ -10,16 +12,72 @@ type Parameter struct {
 // SystemFunctionCall is the function signature for built-in functions
 type SystemFunctionCall func([]interface{}) interface{}
 
+// SystemFunctionTypes works out the parameters and return
+// type of a built-in function that accepts more than one
+// type of argument, such as len(), from the types of the
+// arguments, looking up list and map types in the
+// program's table.  It returns false if it can't
+// accept them
+type SystemFunctionTypes func(types *TypeTable, argTypes []int) ([]Parameter, int, bool)
+
 // Function represents a function
-// definition
+// definition.  Built-in functions provide
+// FunctionCall, while user-defined functions
+// provide BlockNode
 type Function struct {
 	Name           string
 	Parameters     []Parameter
 	ReturnDataType int
 	FunctionCall   SystemFunctionCall
+	BlockNode      *Block
+
+	// ResolveTypes is set for the built-in functions
+	// whose types depend on their arguments.  The
+	// Parameters then only describe the function in
+	// messages, and a parameter that can be of more
+	// than one type has the type TypeNone
+	ResolveTypes SystemFunctionTypes
+
+	// Line and Col give the position of
+	// a user-defined function's name
+	Line int
+	Col  int
 }
 
 // NewFunction ...
 func NewFunction() *Function {
-	return &Function{}
+	return &Function{ReturnDataType: TypeNone}
+}
+
+// Signature returns the function's declaration, such
+// as "add(a number, b number) number", with the
+// names of its types taken from the table
+func (f *Function) Signature(types *TypeTable) string {
+	result := f.Name + "("
+	for i, p := range f.Parameters {
+		if i > 0 {
+			result += ", "
+		}
+		if p.DataType == TypeNone {
+			result += p.Name
+		} else {
+			result += fmt.Sprintf("%s %s", p.Name, types.GetTypeName(p.DataType))
+		}
+	}
+	result += ")"
+
+	if f.ReturnDataType != TypeNone {
+		result += " " + types.GetTypeName(f.ReturnDataType)
+	}
+
+	return result
+}
+
+// AsString return the node as a string
+func (f *Function) AsString(indent string, types *TypeTable) string {
+	result := indent + "Function : " + f.Signature(types)
+	if f.BlockNode != nil {
+		result += "\n" + f.BlockNode.AsString(indent+"  ", types)
+	}
+	return result
 }
@@ -0,0 +1,35 @@
+package ast
+
+// Identifier is a variable named within an expression
+// or as the target of an assignment.  The parser only
+// records the name; the checker resolves it to the
+// symbol it refers to
+type Identifier struct {
+	Name       string
+	SymbolNode Symbol
+
+	Line int
+	Col  int
+}
+
+// NewIdentifier ...
+func NewIdentifier(name string, line int, col int) *Identifier {
+	return &Identifier{Name: name, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the variable, or
+// TypeNone if it has not been resolved
+func (i *Identifier) GetDataType() int {
+	if i.SymbolNode == nil {
+		return TypeNone
+	}
+	return i.SymbolNode.GetDataType()
+}
+
+// AsString return the node as a string
+func (i *Identifier) AsString(indent string, types *TypeTable) string {
+	if i.SymbolNode != nil {
+		return i.SymbolNode.AsString(indent, types)
+	}
+	return indent + "Identifier: " + i.Name
+}
@@ -0,0 +1,39 @@
+package ast
+
+// IfStatement is an "if" statement with an
+// optional "else" branch
+type IfStatement struct {
+	ConditionNode Expression
+	BlockNode     *Block
+
+	// ElseNode is either nil, a *Block or,
+	// for "else if", another *IfStatement
+	ElseNode Statement
+
+	Line int
+	Col  int
+}
+
+// NewIfStatement ...
+func NewIfStatement(c Expression, b *Block, line int, col int) *IfStatement {
+	return &IfStatement{ConditionNode: c, BlockNode: b, Line: line, Col: col}
+}
+
+// AsString return the node as a string
+func (s IfStatement) AsString(indent string, types *TypeTable) string {
+	result := indent + "IfStatement"
+
+	if s.ConditionNode != nil {
+		result += "\n" + s.ConditionNode.AsString("  "+indent, types)
+	}
+
+	if s.BlockNode != nil {
+		result += "\n" + s.BlockNode.AsString("  "+indent, types)
+	}
+
+	if s.ElseNode != nil {
+		result += "\n  " + indent + "Else\n" + s.ElseNode.AsString("    "+indent, types)
+	}
+
+	return result
+}
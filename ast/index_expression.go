@@ -0,0 +1,37 @@
+package ast
+
+import "fmt"
+
+// IndexExpression reads an element of a list, as
+// in a[i], or the value stored under a key of a
+// map, as in m["k"].  It may also be the target
+// of an assignment, which changes that element
+type IndexExpression struct {
+	CollectionNode Expression
+	IndexNode      Expression
+
+	// DataType is the type of the element,
+	// filled in by the checker
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewIndexExpression ...
+func NewIndexExpression(collection Expression, index Expression, line int, col int) *IndexExpression {
+	return &IndexExpression{CollectionNode: collection, IndexNode: index, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the element
+func (i *IndexExpression) GetDataType() int {
+	return i.DataType
+}
+
+// AsString return the node as a string
+func (i *IndexExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("IndexExpression : %s", types.GetTypeName(i.DataType))
+	result += "\n" + i.CollectionNode.AsString("  "+indent, types)
+	result += "\n" + i.IndexNode.AsString("  "+indent, types)
+	return result
+}
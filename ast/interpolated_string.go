@@ -0,0 +1,33 @@
+package ast
+
+// InterpolatedString is a string with expressions
+// embedded in it, as in "total ${a * b}".  Its parts
+// are the text around the expressions, as Strings,
+// and the expressions themselves, which can be of any
+// type.  At runtime each part is converted to a string
+// in the same way print does, and they are joined
+type InterpolatedString struct {
+	PartNodes []Expression
+
+	Line int
+	Col  int
+}
+
+// NewInterpolatedString ...
+func NewInterpolatedString(line int, col int) *InterpolatedString {
+	return &InterpolatedString{PartNodes: []Expression{}, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the result
+func (s *InterpolatedString) GetDataType() int {
+	return TypeString
+}
+
+// AsString return the node as a string
+func (s *InterpolatedString) AsString(indent string, types *TypeTable) string {
+	result := indent + "InterpolatedString"
+	for _, p := range s.PartNodes {
+		result += "\n" + p.AsString("  "+indent, types)
+	}
+	return result
+}
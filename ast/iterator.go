@@ -0,0 +1,114 @@
+package ast
+
+// Iterator steps through the entries of a value being
+// looped over.  Each call to Next returns the key and
+// the value of the next entry, or false once there are
+// no more entries
+type Iterator interface {
+	Next() (interface{}, interface{}, bool)
+}
+
+// Iterable is for any value that can be looped over
+// by a for-in loop.  Each call to Iterate starts a
+// new loop from the first entry
+// Current implementers:
+//    List, Map, String, Range
+type Iterable interface {
+	Iterate() Iterator
+}
+
+// iterationTypes holds the types of the
+// keys and values of an iterable type
+type iterationTypes struct {
+	keyType   int
+	valueType int
+}
+
+// iterableTypes lists the types, other than lists and
+// maps, whose values can be looped over.  A string is
+// looped over by character, each as a string
+var iterableTypes map[int]iterationTypes = map[int]iterationTypes{
+	TypeString: {TypeInt, TypeString},
+	TypeRange:  {TypeInt, TypeInt},
+}
+
+// SetIterationTypes makes values of the data type
+// available to for-in loops, with keys and values
+// of the given types.  The values must implement
+// Iterable
+func SetIterationTypes(dataType int, keyType int, valueType int) {
+	iterableTypes[dataType] = iterationTypes{keyType, valueType}
+}
+
+// IterationTypes returns the types of the keys and
+// values of a data type when it is looped over, and
+// false if it can't be looped over.  The key of a list
+// or a string is the index of the value, and of a map
+// the key the value is stored under
+func (t *TypeTable) IterationTypes(dataType int) (int, int, bool) {
+	switch {
+	case t.IsListType(dataType):
+		return TypeInt, t.ElementType(dataType), true
+	case t.IsMapType(dataType):
+		return TypeString, t.ValueType(dataType), true
+	}
+
+	if result, exists := iterableTypes[dataType]; exists {
+		return result.keyType, result.valueType, true
+	}
+	return TypeNone, TypeNone, false
+}
+
+// listIterator loops over the elements the
+// list had when the loop started
+type listIterator struct {
+	list  *List
+	index int
+	count int
+}
+
+func (i *listIterator) Next() (interface{}, interface{}, bool) {
+	if i.index >= i.count || i.index >= i.list.Len() {
+		return nil, nil, false
+	}
+
+	i.index++
+	return NewIntNumber(int64(i.index - 1)), i.list.Elements[i.index-1], true
+}
+
+// mapIterator loops over the keys the map had when the
+// loop started, in sorted order, skipping any that
+// have since been deleted
+type mapIterator struct {
+	m     *Map
+	keys  []string
+	index int
+}
+
+func (i *mapIterator) Next() (interface{}, interface{}, bool) {
+	for i.index < len(i.keys) {
+		key := i.keys[i.index]
+		i.index++
+		if value, exists := i.m.Get(key); exists {
+			return NewString(key), value, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// stringIterator loops over the
+// characters of a string
+type stringIterator struct {
+	chars []rune
+	index int
+}
+
+func (i *stringIterator) Next() (interface{}, interface{}, bool) {
+	if i.index >= len(i.chars) {
+		return nil, nil, false
+	}
+
+	i.index++
+	return NewIntNumber(int64(i.index - 1)), NewString(string(i.chars[i.index-1])), true
+}
@@ -0,0 +1,44 @@
+package ast
+
+// List is the value of a list at runtime.  Unlike
+// other values a list can be changed, by assigning
+// to an element or appending to it, and the change
+// is seen through every variable that refers to it
+type List struct {
+	Elements []interface{}
+
+	dataType int
+}
+
+// NewList creates a list of the given list type
+func NewList(dataType int, elements []interface{}) *List {
+	return &List{Elements: elements, dataType: dataType}
+}
+
+// GetDataType returns the type of the list
+func (l *List) GetDataType() int {
+	return l.dataType
+}
+
+// Len returns the number of elements
+func (l *List) Len() int {
+	return len(l.Elements)
+}
+
+// Append adds a value to the end of the list
+func (l *List) Append(value interface{}) {
+	l.Elements = append(l.Elements, value)
+}
+
+// Slice returns a new list holding a copy of the
+// elements from low up to, but not including, high
+func (l *List) Slice(low int, high int) *List {
+	elements := make([]interface{}, high-low)
+	copy(elements, l.Elements[low:high])
+	return NewList(l.dataType, elements)
+}
+
+// Iterate starts a loop over the elements of the list
+func (l *List) Iterate() Iterator {
+	return &listIterator{list: l, count: l.Len()}
+}
@@ -0,0 +1,34 @@
+package ast
+
+import "fmt"
+
+// ListLiteral is a list written out in full, such
+// as [1, 2, 3].  Its type is worked out from the
+// elements by the checker, or for an empty list
+// from where it is used
+type ListLiteral struct {
+	ElementNodes []Expression
+	DataType     int
+
+	Line int
+	Col  int
+}
+
+// NewListLiteral ...
+func NewListLiteral(line int, col int) *ListLiteral {
+	return &ListLiteral{ElementNodes: []Expression{}, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the list
+func (l *ListLiteral) GetDataType() int {
+	return l.DataType
+}
+
+// AsString return the node as a string
+func (l *ListLiteral) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("ListLiteral : %s", types.GetTypeName(l.DataType))
+	for _, e := range l.ElementNodes {
+		result += "\n" + e.AsString("  "+indent, types)
+	}
+	return result
+}
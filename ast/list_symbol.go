@@ -0,0 +1,31 @@
+package ast
+
+// ListSymbol represents a variable
+// that holds a list
+type ListSymbol struct {
+	symbolSlot
+
+	Name     string
+	dataType int
+}
+
+// NewListSymbol ...
+func NewListSymbol(name string, dataType int) *ListSymbol {
+	return &ListSymbol{Name: name, dataType: dataType}
+}
+
+// AsString returns a string representation of the
+// symbol
+func (s *ListSymbol) AsString(indent string, types *TypeTable) string {
+	return formatSymbolAsString(s, indent, types)
+}
+
+// GetName ...
+func (s ListSymbol) GetName() string {
+	return s.Name
+}
+
+// GetDataType returns the data type identifier
+func (s *ListSymbol) GetDataType() int {
+	return s.dataType
+}
@@ -0,0 +1,31 @@
+package ast
+
+// ListType returns the data type of a list with
+// the given type of element
+func (t *TypeTable) ListType(elementType int) int {
+	if result, exists := t.listTypes[elementType]; exists {
+		return result
+	}
+
+	result := t.newType("[]" + t.GetTypeName(elementType))
+	t.listTypes[elementType] = result
+	t.elementTypes[result] = elementType
+	return result
+}
+
+// IsListType returns true if the data
+// type is a list of any kind
+func (t *TypeTable) IsListType(dataType int) bool {
+	_, exists := t.elementTypes[dataType]
+	return exists
+}
+
+// ElementType returns the type of the elements
+// of a list type, or TypeNone if it is not a list
+func (t *TypeTable) ElementType(listType int) int {
+	if result, exists := t.elementTypes[listType]; exists {
+		return result
+	}
+
+	return TypeNone
+}
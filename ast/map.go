@@ -0,0 +1,71 @@
+package ast
+
+import "sort"
+
+// Map is the value of a map at runtime.  Like a list,
+// a map can be changed, and the change is seen through
+// every variable that refers to it.  Its keys are always
+// visited in sorted order, so a program behaves the same
+// way each time it runs
+type Map struct {
+	Entries map[string]interface{}
+
+	dataType int
+}
+
+// NewMap creates an empty map of the given map type
+func NewMap(dataType int) *Map {
+	return &Map{Entries: map[string]interface{}{}, dataType: dataType}
+}
+
+// GetDataType returns the type of the map
+func (m *Map) GetDataType() int {
+	return m.dataType
+}
+
+// Len returns the number of entries
+func (m *Map) Len() int {
+	return len(m.Entries)
+}
+
+// Get returns the value stored under the key,
+// and whether there is one
+func (m *Map) Get(key string) (interface{}, bool) {
+	result, exists := m.Entries[key]
+	return result, exists
+}
+
+// Set stores a value under the key, replacing
+// any value already there
+func (m *Map) Set(key string, value interface{}) {
+	m.Entries[key] = value
+}
+
+// Has returns true if there is a value
+// stored under the key
+func (m *Map) Has(key string) bool {
+	_, exists := m.Entries[key]
+	return exists
+}
+
+// Delete removes the key and its value,
+// if there is one
+func (m *Map) Delete(key string) {
+	delete(m.Entries, key)
+}
+
+// Keys returns the keys of the map in sorted order
+func (m *Map) Keys() []string {
+	result := make([]string, 0, len(m.Entries))
+	for k := range m.Entries {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Iterate starts a loop over the entries of the
+// map, in the order of their keys
+func (m *Map) Iterate() Iterator {
+	return &mapIterator{m: m, keys: m.Keys()}
+}
@@ -0,0 +1,36 @@
+package ast
+
+import "fmt"
+
+// MapLiteral is a map written out in full, such
+// as {"a": 1, "b": 2}.  Like a list literal, its
+// type is worked out from the values by the checker,
+// or for an empty map from where it is used
+type MapLiteral struct {
+	KeyNodes   []Expression
+	ValueNodes []Expression
+	DataType   int
+
+	Line int
+	Col  int
+}
+
+// NewMapLiteral ...
+func NewMapLiteral(line int, col int) *MapLiteral {
+	return &MapLiteral{KeyNodes: []Expression{}, ValueNodes: []Expression{}, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the map
+func (m *MapLiteral) GetDataType() int {
+	return m.DataType
+}
+
+// AsString return the node as a string
+func (m *MapLiteral) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("MapLiteral : %s", types.GetTypeName(m.DataType))
+	for i := range m.KeyNodes {
+		result += "\n" + m.KeyNodes[i].AsString("  "+indent, types)
+		result += "\n" + m.ValueNodes[i].AsString("    "+indent, types)
+	}
+	return result
+}
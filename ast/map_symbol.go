@@ -0,0 +1,31 @@
+package ast
+
+// MapSymbol represents a variable
+// that holds a map
+type MapSymbol struct {
+	symbolSlot
+
+	Name     string
+	dataType int
+}
+
+// NewMapSymbol ...
+func NewMapSymbol(name string, dataType int) *MapSymbol {
+	return &MapSymbol{Name: name, dataType: dataType}
+}
+
+// AsString returns a string representation of the
+// symbol
+func (s *MapSymbol) AsString(indent string, types *TypeTable) string {
+	return formatSymbolAsString(s, indent, types)
+}
+
+// GetName ...
+func (s MapSymbol) GetName() string {
+	return s.Name
+}
+
+// GetDataType returns the data type identifier
+func (s *MapSymbol) GetDataType() int {
+	return s.dataType
+}
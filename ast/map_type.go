@@ -0,0 +1,31 @@
+package ast
+
+// MapType returns the data type of a map from
+// strings to values of the given type
+func (t *TypeTable) MapType(valueType int) int {
+	if result, exists := t.mapTypes[valueType]; exists {
+		return result
+	}
+
+	result := t.newType("map[string]" + t.GetTypeName(valueType))
+	t.mapTypes[valueType] = result
+	t.valueTypes[result] = valueType
+	return result
+}
+
+// IsMapType returns true if the data
+// type is a map of any kind
+func (t *TypeTable) IsMapType(dataType int) bool {
+	_, exists := t.valueTypes[dataType]
+	return exists
+}
+
+// ValueType returns the type of the values of
+// a map type, or TypeNone if it is not a map
+func (t *TypeTable) ValueType(mapType int) int {
+	if result, exists := t.valueTypes[mapType]; exists {
+		return result
+	}
+
+	return TypeNone
+}
@@ -10,6 +10,6 @@ func NewNullStatement() *NullStatement {
 }
 
 // AsString return the node as a string
-func (s NullStatement) AsString(indent string) string {
+func (s NullStatement) AsString(indent string, types *TypeTable) string {
 	return indent + "NullStatement"
 }
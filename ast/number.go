@@ -1,12 +1,22 @@
 package ast
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 )
 
-// Constants for different types
-// of numbers
+// Errors returned by the arithmetic
+// operations on numbers
+var (
+	ErrDivisionByZero  = errors.New("Division by zero")
+	ErrIntegerOverflow = errors.New("Integer overflow")
+)
+
+// Constants for different types of numbers.
+// These are the kinds of value a number holds at
+// runtime, not the data types TypeInt and TypeFloat
 const (
 	IntType = iota
 	FloatType
@@ -16,19 +26,26 @@ const (
 // can stand in place of a number
 // Current implementers:
 //    Number
-//    NumberSymbol
 type NumberValue interface {
 	GetDataType() int
+	GetNumberType() int
 	GetIntValue() int64
 	GetFloatValue() float64
 	SetValue(value interface{})
-	AsString(indent string) string
+	AsString(indent string, types *TypeTable) string
 	ToString() string
+	ToInt() (Number, error)
+	ToFloat() Number
 
-	Add(n2 NumberValue) Number
-	Sub(n2 NumberValue) Number
-	Mult(n2 NumberValue) Number
-	Div(n2 NumberValue) Number
+	Add(n2 NumberValue) (Number, error)
+	Sub(n2 NumberValue) (Number, error)
+	Mult(n2 NumberValue) (Number, error)
+	Div(n2 NumberValue) (Number, error)
+	IntDiv(n2 NumberValue) (Number, error)
+	Mod(n2 NumberValue) (Number, error)
+	Pow(n2 NumberValue) (Number, error)
+	Negate() (Number, error)
+	Compare(n2 NumberValue) int
 }
 
 // Number represents both a float
@@ -36,18 +53,17 @@ type NumberValue interface {
 type Number struct {
 	valueInt   int64
 	valueFloat float64
-	dataType   int
 	numberType int
 }
 
 // NewIntNumber creates an int number
 func NewIntNumber(v int64) *Number {
-	return &Number{valueInt: v, dataType: TypeNumber, numberType: IntType}
+	return &Number{valueInt: v, numberType: IntType}
 }
 
 // NewFloatNumber creates a float number
 func NewFloatNumber(v float64) *Number {
-	return &Number{valueFloat: v, dataType: TypeNumber, numberType: FloatType}
+	return &Number{valueFloat: v, numberType: FloatType}
 }
 
 // GetIntValue returns the value as an int64, regardless of type
@@ -69,9 +85,18 @@ func (n *Number) GetFloatValue() float64 {
 	return n.valueFloat
 }
 
-// GetDataType ...
+// GetDataType returns TypeInt or TypeFloat, as
+// a literal number has the type of its value
 func (n *Number) GetDataType() int {
-	return n.dataType
+	if n.numberType == IntType {
+		return TypeInt
+	}
+	return TypeFloat
+}
+
+// GetNumberType returns IntType or FloatType
+func (n *Number) GetNumberType() int {
+	return n.numberType
 }
 
 // SetValue ...
@@ -105,14 +130,20 @@ func (n *Number) SetValue(value interface{}) {
 		n.numberType = IntType
 	case float32:
 		n.valueFloat = float64(value.(float32))
+		n.numberType = FloatType
 	case float64:
 		n.valueFloat = value.(float64)
+		n.numberType = FloatType
 	default:
 		panic("Invalid data for number")
 	}
 }
 
-// ToString returns the number formatted as a string
+// ToString returns the number formatted as a string.
+// Integers are written as plain digits, while floats
+// use the fewest digits needed to represent the value
+// exactly, never in exponent form: 2.50 becomes "2.5"
+// and 1e6 becomes "1000000".
 func (n *Number) ToString() string {
 	if n.numberType == IntType {
 		return strconv.FormatInt(n.valueInt, 10)
@@ -120,36 +151,171 @@ func (n *Number) ToString() string {
 	return strconv.FormatFloat(n.valueFloat, 'f', -1, 64)
 }
 
-// Add adds two numbers, irrespective of type
-func (n Number) Add(n2 NumberValue) Number {
-	if n.GetDataType() == FloatType || n2.GetDataType() == FloatType {
-		return *NewFloatNumber(n.GetFloatValue() + n2.GetFloatValue())
+// ToInt converts the number to an int, dropping any
+// fraction.  A float that is out of the range of an
+// int, or is not a number at all, can't be converted.
+func (n Number) ToInt() (Number, error) {
+	if n.GetNumberType() == IntType {
+		return n, nil
+	}
+
+	v := math.Trunc(n.GetFloatValue())
+	if math.IsNaN(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+		return Number{}, fmt.Errorf("Cannot convert %s to int", n.ToString())
+	}
+	return *NewIntNumber(int64(v)), nil
+}
+
+// ToFloat converts the number to a float
+func (n Number) ToFloat() Number {
+	return *NewFloatNumber(n.GetFloatValue())
+}
+
+// Add adds two numbers.  The result is an int
+// if both numbers are ints, otherwise a float.
+func (n Number) Add(n2 NumberValue) (Number, error) {
+	if n.GetNumberType() == FloatType || n2.GetNumberType() == FloatType {
+		return *NewFloatNumber(n.GetFloatValue() + n2.GetFloatValue()), nil
 	}
-	return *NewIntNumber(n.GetIntValue() + n2.GetIntValue())
+	return intResult(addInt(n.GetIntValue(), n2.GetIntValue()))
 }
 
 // Sub subtracts two numbers
-func (n Number) Sub(n2 NumberValue) Number {
-	if n.GetDataType() == FloatType || n2.GetDataType() == FloatType {
-		return *NewFloatNumber(n.GetFloatValue() - n2.GetFloatValue())
+func (n Number) Sub(n2 NumberValue) (Number, error) {
+	if n.GetNumberType() == FloatType || n2.GetNumberType() == FloatType {
+		return *NewFloatNumber(n.GetFloatValue() - n2.GetFloatValue()), nil
 	}
-	return *NewIntNumber(n.GetIntValue() - n2.GetIntValue())
+	return intResult(subInt(n.GetIntValue(), n2.GetIntValue()))
 }
 
 // Mult multiplies two numbers
-func (n Number) Mult(n2 NumberValue) Number {
-	if n.GetDataType() == FloatType || n2.GetDataType() == FloatType {
-		return *NewFloatNumber(n.GetFloatValue() * n2.GetFloatValue())
+func (n Number) Mult(n2 NumberValue) (Number, error) {
+	if n.GetNumberType() == FloatType || n2.GetNumberType() == FloatType {
+		return *NewFloatNumber(n.GetFloatValue() * n2.GetFloatValue()), nil
+	}
+	return intResult(multInt(n.GetIntValue(), n2.GetIntValue()))
+}
+
+// Div divides two numbers.  The result is
+// always a float, even if both are ints.
+func (n Number) Div(n2 NumberValue) (Number, error) {
+	if n2.GetFloatValue() == 0 {
+		return Number{}, ErrDivisionByZero
+	}
+	return *NewFloatNumber(n.GetFloatValue() / n2.GetFloatValue()), nil
+}
+
+// IntDiv divides two numbers, dropping any fraction so
+// the result is rounded towards zero, which matches Mod.
+// The result is an int if both numbers are ints.
+func (n Number) IntDiv(n2 NumberValue) (Number, error) {
+	if n2.GetFloatValue() == 0 {
+		return Number{}, ErrDivisionByZero
+	}
+	if n.GetNumberType() == IntType && n2.GetNumberType() == IntType {
+		a, b := n.GetIntValue(), n2.GetIntValue()
+		if a == math.MinInt64 && b == -1 {
+			return Number{}, ErrIntegerOverflow
+		}
+		return *NewIntNumber(a / b), nil
+	}
+	return *NewFloatNumber(math.Trunc(n.GetFloatValue() / n2.GetFloatValue())), nil
+}
+
+// Mod returns the remainder of dividing two numbers.
+// The result has the sign of n, and is an int if both
+// numbers are ints.
+func (n Number) Mod(n2 NumberValue) (Number, error) {
+	if n2.GetFloatValue() == 0 {
+		return Number{}, ErrDivisionByZero
+	}
+	if n.GetNumberType() == IntType && n2.GetNumberType() == IntType {
+		return *NewIntNumber(n.GetIntValue() % n2.GetIntValue()), nil
+	}
+	return *NewFloatNumber(math.Mod(n.GetFloatValue(), n2.GetFloatValue())), nil
+}
+
+// Pow raises n to the power of n2.  The result is an
+// int if both numbers are ints and n2 is not negative.
+// Raising zero to a negative power divides by zero.
+func (n Number) Pow(n2 NumberValue) (Number, error) {
+	if n.GetFloatValue() == 0 && n2.GetFloatValue() < 0 {
+		return Number{}, ErrDivisionByZero
+	}
+	if n.GetNumberType() == IntType && n2.GetNumberType() == IntType && n2.GetIntValue() >= 0 {
+		result, ok := int64(1), true
+		for base, exp := n.GetIntValue(), n2.GetIntValue(); exp > 0 && ok; {
+			if exp&1 == 1 {
+				result, ok = multInt(result, base)
+			}
+			if exp >>= 1; exp > 0 && ok {
+				base, ok = multInt(base, base)
+			}
+		}
+		return intResult(result, ok)
+	}
+	return *NewFloatNumber(math.Pow(n.GetFloatValue(), n2.GetFloatValue())), nil
+}
+
+// Negate returns the number with its sign reversed
+func (n Number) Negate() (Number, error) {
+	if n.GetNumberType() == IntType {
+		return intResult(subInt(0, n.GetIntValue()))
+	}
+	return *NewFloatNumber(-n.GetFloatValue()), nil
+}
+
+// intResult converts the result of an int operation,
+// where ok is false if the result overflowed
+func intResult(v int64, ok bool) (Number, error) {
+	if !ok {
+		return Number{}, ErrIntegerOverflow
 	}
-	return *NewIntNumber(n.GetIntValue() * n2.GetIntValue())
+	return *NewIntNumber(v), nil
+}
+
+func addInt(a int64, b int64) (int64, bool) {
+	result := a + b
+	return result, (b >= 0) == (result >= a)
 }
 
-// Div divides two numbers
-func (n Number) Div(n2 NumberValue) Number {
-	return *NewFloatNumber(n.GetFloatValue() / n2.GetFloatValue())
+func subInt(a int64, b int64) (int64, bool) {
+	result := a - b
+	return result, (b >= 0) == (result <= a)
+}
+
+func multInt(a int64, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	result := a * b
+	return result, result/b == a && !(a == -1 && b == math.MinInt64) && !(b == -1 && a == math.MinInt64)
+}
+
+// Compare returns -1 if this number is less than n2,
+// 1 if it is greater, and 0 if they are equal.  Two
+// ints are compared exactly, without going via float
+func (n Number) Compare(n2 NumberValue) int {
+	if n.GetNumberType() == IntType && n2.GetNumberType() == IntType {
+		i1, i2 := n.GetIntValue(), n2.GetIntValue()
+		if i1 < i2 {
+			return -1
+		} else if i1 > i2 {
+			return 1
+		}
+		return 0
+	}
+
+	v1, v2 := n.GetFloatValue(), n2.GetFloatValue()
+	if v1 < v2 {
+		return -1
+	} else if v1 > v2 {
+		return 1
+	}
+	return 0
 }
 
 // AsString returns a string representation of the node
-func (n *Number) AsString(indent string) string {
+func (n *Number) AsString(indent string, types *TypeTable) string {
 	return indent + fmt.Sprintf("Signed number: '%s'", n.ToString())
 }
@@ -0,0 +1,59 @@
+package ast
+
+// List the posible operations
+// allowable in an expression
+const (
+	NoOperator = iota
+	PlusOperator
+	MinusOperator
+	MultOperator
+	DivOperator
+	ModuloOperator
+	EqualsOperator
+	NotEqualsOperator
+	LessThanOperator
+	LessThanEqualsOperator
+	GreaterThanOperator
+	GreaterThanEqualsOperator
+	AndOperator
+	OrOperator
+	PowerOperator
+	NotOperator
+	IntDivOperator
+)
+
+var operatorSymbols []string = []string{
+	"",
+	"+",
+	"-",
+	"*",
+	"/",
+	"%",
+	"==",
+	"!=",
+	"<",
+	"<=",
+	">",
+	">=",
+	"and",
+	"or",
+	"^",
+	"not",
+	"//",
+}
+
+// GetOperatorSymbol returns the operator
+// as it is written in source
+func GetOperatorSymbol(operator int) string {
+	if operator >= 0 && operator < len(operatorSymbols) {
+		return operatorSymbols[operator]
+	}
+
+	return "unknown"
+}
+
+// IsComparisonOperator returns true for the
+// operators that compare two values
+func IsComparisonOperator(operator int) bool {
+	return operator >= EqualsOperator && operator <= GreaterThanEqualsOperator
+}
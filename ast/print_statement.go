@@ -1,32 +1,25 @@
 package ast
 
-// PrintStatement is a "println" statement
+// PrintStatement is a "print" or "println"
+// statement, which can print an expression
+// of any type
 type PrintStatement struct {
-	StringExpressionNode *StringExpression
-	NumExpressionNode    *NumExpression
-
-	ExpressionTypeID int
+	ExpressionNode Expression
 
 	WithEndline bool
-}
-
-// NewStringPrintStatement ...
-func NewStringPrintStatement(s *StringExpression, endline bool) *PrintStatement {
-	return &PrintStatement{StringExpressionNode: s, ExpressionTypeID: StringExpressionType, WithEndline: endline}
-}
 
-// NewNumPrintStatement ...
-func NewNumPrintStatement(n *NumExpression, endline bool) *PrintStatement {
-	return &PrintStatement{NumExpressionNode: n, ExpressionTypeID: NumExpressionType, WithEndline: endline}
+	Line int
+	Col  int
 }
 
-// NewEmptyPrintStatement ...
-func NewEmptyPrintStatement(endline bool) *PrintStatement {
-	return &PrintStatement{NumExpressionNode: nil, ExpressionTypeID: EmptyExpressionType, WithEndline: endline}
+// NewPrintStatement creates a print statement.  The
+// expression is nil for a println with nothing to print
+func NewPrintStatement(exp Expression, endline bool, line int, col int) *PrintStatement {
+	return &PrintStatement{ExpressionNode: exp, WithEndline: endline, Line: line, Col: col}
 }
 
 // AsString return the node as a string
-func (s PrintStatement) AsString(indent string) string {
+func (s PrintStatement) AsString(indent string, types *TypeTable) string {
 	var result string
 	if s.WithEndline {
 		result = indent + "PrintlnStatement"
@@ -34,15 +27,8 @@ func (s PrintStatement) AsString(indent string) string {
 		result = indent + "PrintStatement"
 	}
 
-	switch s.ExpressionTypeID {
-	case StringExpressionType:
-		if s.StringExpressionNode != nil {
-			result += "\n" + s.StringExpressionNode.AsString("  "+indent)
-		}
-	case NumExpressionType:
-		if s.NumExpressionNode != nil {
-			result += "\n" + s.NumExpressionNode.AsString("  "+indent)
-		}
+	if s.ExpressionNode != nil {
+		result += "\n" + s.ExpressionNode.AsString("  "+indent, types)
 	}
 
 	return result
@@ -4,18 +4,43 @@ package ast
 // into a program
 type Program struct {
 	BlockNode *Block
+	Functions []*Function
+	Structs   []*StructDeclaration
+
+	// Types holds the data types of the program,
+	// including its list, map and struct types
+	Types *TypeTable
 }
 
 // NewProgram ...
-func NewProgram(blockNode *Block) *Program {
-	return &Program{BlockNode: blockNode}
+func NewProgram(blockNode *Block, types *TypeTable) *Program {
+	return &Program{BlockNode: blockNode, Functions: []*Function{}, Structs: []*StructDeclaration{}, Types: types}
+}
+
+// GetFunction returns the function with the
+// given name, or nil if there isn't one
+func (p *Program) GetFunction(name string) *Function {
+	for _, f := range p.Functions {
+		if f.Name == name {
+			return f
+		}
+	}
+
+	return nil
 }
 
 // AsString return the node as a string
 func (p *Program) AsString(indent string) string {
+	types := p.Types
 	result := indent + "Program"
-	if p.BlockNode != nil {
-		result += "\n" + p.BlockNode.AsString(indent+"  ")
+	for _, s := range p.Structs {
+		result += "\n" + s.AsString(indent+"  ", types)
+	}
+	for _, f := range p.Functions {
+		result += "\n" + f.AsString(indent+"  ", types)
+	}
+	if p.BlockNode != nil && p.BlockNode.StatementsNode != nil {
+		result += "\n" + p.BlockNode.AsString(indent+"  ", types)
 	}
 	return result
 }
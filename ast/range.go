@@ -0,0 +1,58 @@
+package ast
+
+import "fmt"
+
+// Range is the value returned by range(), which counts
+// from Start up to, but not including, End, in steps of
+// Step.  A negative step counts down to End instead
+type Range struct {
+	Start int64
+	End   int64
+	Step  int64
+}
+
+// NewRange ...
+func NewRange(start int64, end int64, step int64) *Range {
+	return &Range{Start: start, End: end, Step: step}
+}
+
+// GetDataType returns the type of the range
+func (r *Range) GetDataType() int {
+	return TypeRange
+}
+
+// Iterate starts a loop over the numbers in the range
+func (r *Range) Iterate() Iterator {
+	return &rangeIterator{r: r, next: r.Start}
+}
+
+// ToString returns the range as it would be created
+func (r *Range) ToString() string {
+	return fmt.Sprintf("range(%d, %d, %d)", r.Start, r.End, r.Step)
+}
+
+// rangeIterator loops over the numbers of a range.
+// done is set once the next number would be past the
+// largest or smallest int, so the loop ends there
+// rather than wrapping around
+type rangeIterator struct {
+	r     *Range
+	next  int64
+	index int64
+	done  bool
+}
+
+func (i *rangeIterator) Next() (interface{}, interface{}, bool) {
+	if i.done || (i.r.Step > 0 && i.next >= i.r.End) || (i.r.Step < 0 && i.next <= i.r.End) || i.r.Step == 0 {
+		return nil, nil, false
+	}
+
+	key, value := NewIntNumber(i.index), NewIntNumber(i.next)
+	i.index++
+
+	var ok bool
+	if i.next, ok = addInt(i.next, i.r.Step); !ok {
+		i.done = true
+	}
+	return key, value, true
+}
@@ -0,0 +1,27 @@
+package ast
+
+// ReturnStatement exits the current function,
+// optionally with a value
+type ReturnStatement struct {
+	ExpressionNode Expression
+	DataType       int
+
+	Line int
+	Col  int
+}
+
+// NewReturnStatement ...
+func NewReturnStatement(line int, col int) *ReturnStatement {
+	return &ReturnStatement{DataType: TypeNone, Line: line, Col: col}
+}
+
+// AsString return the node as a string
+func (s ReturnStatement) AsString(indent string, types *TypeTable) string {
+	result := indent + "ReturnStatement"
+
+	if s.ExpressionNode != nil {
+		result += "\n" + s.ExpressionNode.AsString("  "+indent, types)
+	}
+
+	return result
+}
@@ -0,0 +1,40 @@
+package ast
+
+import "fmt"
+
+// SliceExpression copies part of a list, as in
+// a[low:high].  Either bound may be left out, and
+// then the slice starts at the beginning or runs
+// to the end of the list
+type SliceExpression struct {
+	ListNode Expression
+	LowNode  Expression
+	HighNode Expression
+
+	Line int
+	Col  int
+}
+
+// NewSliceExpression ...
+func NewSliceExpression(list Expression, low Expression, high Expression, line int, col int) *SliceExpression {
+	return &SliceExpression{ListNode: list, LowNode: low, HighNode: high, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the list
+// being sliced, which is also the result
+func (s *SliceExpression) GetDataType() int {
+	return s.ListNode.GetDataType()
+}
+
+// AsString return the node as a string
+func (s *SliceExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("SliceExpression : %s", types.GetTypeName(s.GetDataType()))
+	result += "\n" + s.ListNode.AsString("  "+indent, types)
+	if s.LowNode != nil {
+		result += "\n" + s.LowNode.AsString("  "+indent, types)
+	}
+	if s.HighNode != nil {
+		result += "\n" + s.HighNode.AsString("  "+indent, types)
+	}
+	return result
+}
@@ -3,5 +3,5 @@ package ast
 // Statement is the interface for AST
 // statements
 type Statement interface {
-	AsString(indent string) string
+	AsString(indent string, types *TypeTable) string
 }
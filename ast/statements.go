@@ -13,12 +13,12 @@ func NewStatements(stmts []Statement) *Statements {
 }
 
 // AsString return the node as a string
-func (s *Statements) AsString(indent string) string {
+func (s *Statements) AsString(indent string, types *TypeTable) string {
 	result := indent + "Statements"
 	if s.StatementListNode != nil {
 		for _, v := range s.StatementListNode {
 			if !reflect.ValueOf(v).IsNil() {
-				result += "\n" + v.AsString(indent+"  ")
+				result += "\n" + v.AsString(indent+"  ", types)
 			}
 		}
 	}
@@ -1,19 +1,15 @@
 package ast
 
-import (
-	"fmt"
-	"strings"
-)
+import "fmt"
 
 // StringValue is for any value that
 // can stand in place of a string
 // Current implementers:
 //    String
-//    StringSymbol
 type StringValue interface {
 	GetValue() string
 	SetValue(value interface{})
-	AsString(indent string) string
+	AsString(indent string, types *TypeTable) string
 }
 
 // String represents a string terminal
@@ -23,14 +19,19 @@ type String struct {
 
 // NewString ...
 func NewString(value string) *String {
-	return &String{value: strings.Trim(value, "\"")}
+	return &String{value: value}
 }
 
 // AsString return the node as a string
-func (s *String) AsString(indent string) string {
+func (s *String) AsString(indent string, types *TypeTable) string {
 	return indent + fmt.Sprintf("String: '%s'", s.value)
 }
 
+// GetDataType returns the type of this string
+func (s *String) GetDataType() int {
+	return TypeString
+}
+
 // GetValue returns the value of this string
 func (s *String) GetValue() string {
 	return s.value
@@ -48,6 +49,10 @@ func (s *String) SetValue(value interface{}) {
 	default:
 		panic(fmt.Errorf("Invalid data type for assignment to string : %T", value))
 	}
+}
 
-	s.value = strings.Trim(s.value, "\"")
+// Iterate starts a loop over the
+// characters of this string
+func (s *String) Iterate() Iterator {
+	return &stringIterator{chars: []rune(s.value)}
 }
@@ -3,25 +3,21 @@ package ast
 // StringSymbol represents a symbol discovered
 // in parsing
 type StringSymbol struct {
-	Name       string
-	StringData StringValue
-	dataType   int
+	symbolSlot
+
+	Name     string
+	dataType int
 }
 
 // NewStringSymbol ...
 func NewStringSymbol(name string) *StringSymbol {
-	return &StringSymbol{Name: name, dataType: TypeString, StringData: NewString("")}
+	return &StringSymbol{Name: name, dataType: TypeString}
 }
 
 // AsString returns a string representation of the
 // symbol
-func (s *StringSymbol) AsString(indent string) string {
-	return formatSymbolAsString(s, indent)
-}
-
-// GetValue returns the value of this string
-func (s *StringSymbol) GetValue() string {
-	return s.StringData.GetValue()
+func (s *StringSymbol) AsString(indent string, types *TypeTable) string {
+	return formatSymbolAsString(s, indent, types)
 }
 
 // GetName ...
@@ -33,14 +29,3 @@ func (s StringSymbol) GetName() string {
 func (s *StringSymbol) GetDataType() int {
 	return s.dataType
 }
-
-// SetValue value to this symbol; it will determine
-// the type
-func (s *StringSymbol) SetValue(value interface{}) {
-	s.StringData.SetValue(value)
-}
-
-// ToString ...
-func (s *StringSymbol) ToString() string {
-	return s.StringData.GetValue()
-}
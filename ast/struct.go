@@ -0,0 +1,27 @@
+package ast
+
+// Struct is the value of a struct at runtime.  It holds
+// a value for each field of its declaration, in the same
+// order.  Like a list, a struct can be changed, and the
+// change is seen through every variable that refers to it
+type Struct struct {
+	Fields []interface{}
+
+	declaration *StructDeclaration
+}
+
+// NewStruct creates a struct with the given field values
+func NewStruct(declaration *StructDeclaration, fields []interface{}) *Struct {
+	return &Struct{Fields: fields, declaration: declaration}
+}
+
+// GetDataType returns the type of the struct
+func (s *Struct) GetDataType() int {
+	return s.declaration.DataType
+}
+
+// GetDeclaration returns the declaration
+// of the struct's type
+func (s *Struct) GetDeclaration() *StructDeclaration {
+	return s.declaration
+}
@@ -0,0 +1,47 @@
+package ast
+
+import "fmt"
+
+// Field is the definition
+// of a field of a struct
+type Field struct {
+	Name     string
+	DataType int
+}
+
+// StructDeclaration declares a struct type, as in
+// "type Point struct { x number; y number }"
+type StructDeclaration struct {
+	Name     string
+	Fields   []Field
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewStructDeclaration ...
+func NewStructDeclaration(name string, dataType int, line int, col int) *StructDeclaration {
+	return &StructDeclaration{Name: name, Fields: []Field{}, DataType: dataType, Line: line, Col: col}
+}
+
+// FieldIndex returns the position of the
+// named field, or -1 if there is no such field
+func (s *StructDeclaration) FieldIndex(name string) int {
+	for i, f := range s.Fields {
+		if f.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// AsString return the node as a string
+func (s *StructDeclaration) AsString(indent string, types *TypeTable) string {
+	result := indent + "StructDeclaration : " + s.Name
+	for _, f := range s.Fields {
+		result += "\n" + indent + fmt.Sprintf("  Field: %-20s  %-12s", f.Name, types.GetTypeName(f.DataType))
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package ast
+
+import "fmt"
+
+// StructLiteral creates a struct, as in Point{x: 1, y: 2}.
+// Any field that is not given a value is set to the zero
+// value of its type
+type StructLiteral struct {
+	FieldNames []string
+	ValueNodes []Expression
+	DataType   int
+
+	// FieldIndexes gives the position of each named field
+	// in the struct's declaration, filled in by the checker
+	FieldIndexes []int
+
+	Line int
+	Col  int
+}
+
+// NewStructLiteral ...
+func NewStructLiteral(dataType int, line int, col int) *StructLiteral {
+	return &StructLiteral{FieldNames: []string{}, ValueNodes: []Expression{}, DataType: dataType, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the struct
+func (s *StructLiteral) GetDataType() int {
+	return s.DataType
+}
+
+// AsString return the node as a string
+func (s *StructLiteral) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("StructLiteral : %s", types.GetTypeName(s.DataType))
+	for i, n := range s.FieldNames {
+		result += "\n" + indent + "  Field: " + n
+		result += "\n" + s.ValueNodes[i].AsString("    "+indent, types)
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package ast
+
+// StructSymbol represents a variable
+// that holds a struct
+type StructSymbol struct {
+	symbolSlot
+
+	Name     string
+	dataType int
+}
+
+// NewStructSymbol ...
+func NewStructSymbol(name string, dataType int) *StructSymbol {
+	return &StructSymbol{Name: name, dataType: dataType}
+}
+
+// AsString returns a string representation of the
+// symbol
+func (s *StructSymbol) AsString(indent string, types *TypeTable) string {
+	return formatSymbolAsString(s, indent, types)
+}
+
+// GetName ...
+func (s StructSymbol) GetName() string {
+	return s.Name
+}
+
+// GetDataType returns the data type identifier
+func (s *StructSymbol) GetDataType() int {
+	return s.dataType
+}
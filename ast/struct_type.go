@@ -0,0 +1,35 @@
+package ast
+
+// StructType returns the data type of the struct type
+// with the given name.  A struct type is created when
+// its name is first used, which may be before it is
+// declared, so until then it has a nil declaration
+func (t *TypeTable) StructType(name string) int {
+	if result, exists := t.structTypes[name]; exists {
+		return result
+	}
+
+	result := t.newType(name)
+	t.structTypes[name] = result
+	t.structDeclarations[result] = nil
+	return result
+}
+
+// IsStructType returns true if the data
+// type is a struct type of any kind
+func (t *TypeTable) IsStructType(dataType int) bool {
+	_, exists := t.structDeclarations[dataType]
+	return exists
+}
+
+// DeclareStruct records the declaration of
+// a struct type, once it has been checked
+func (t *TypeTable) DeclareStruct(s *StructDeclaration) {
+	t.structDeclarations[s.DataType] = s
+}
+
+// GetStructDeclaration returns the declaration of a
+// struct type, or nil if it has not been declared
+func (t *TypeTable) GetStructDeclaration(dataType int) *StructDeclaration {
+	return t.structDeclarations[dataType]
+}
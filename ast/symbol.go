@@ -2,51 +2,140 @@ package ast
 
 import "fmt"
 
-// Data types
+// Data types.  A number holds either an int or a
+// float, and which it holds is only known when the
+// program runs; an int or a float always holds that
+// kind of number.  A range is only made by range(),
+// to be looped over.  TypeStringList is the list type
+// []string, which every TypeTable starts with so that
+// built-in functions such as keys() can return one
 const (
 	TypeString = iota
 	TypeNumber
+	TypeBool
+	TypeInt
+	TypeFloat
+	TypeNone
+	TypeRange
+	TypeStringList
 )
 
+// typeNames holds the names of the built-in types.  The
+// names of other list, map and struct types are added
+// to the TypeTable of the program that uses them
 var typeNames []string = []string{
 	"string",
 	"number",
+	"bool",
+	"int",
+	"float",
+	"none",
+	"range",
+	"[]string",
 }
 
-// GetTypeName ...
-func GetTypeName(dataType int) string {
-	if dataType >= 0 && dataType < len(typeNames) {
-		return typeNames[dataType]
+// IsNumericType returns true for the
+// types that hold numbers
+func IsNumericType(dataType int) bool {
+	return dataType == TypeNumber || dataType == TypeInt || dataType == TypeFloat
+}
+
+// IsAssignable checks whether a value of type from can
+// be used where a value of type to is expected.  An int
+// widens to a float, and an int or a float can be used
+// as a number, but going the other way needs a cast
+func IsAssignable(to int, from int) bool {
+	switch {
+	case to == from:
+		return true
+	case to == TypeFloat:
+		return from == TypeInt
+	case to == TypeNumber:
+		return IsNumericType(from)
 	}
 
-	return "unknown"
+	return false
 }
 
-// Symbol interface represents any
-// type of symbol
+// CommonType returns the type that values of both
+// types can be used as, such as the type of a list
+// with elements of each.  An int and a float have
+// the common type float, and any other two numeric
+// types have the common type number
+func CommonType(t1 int, t2 int) (int, bool) {
+	switch {
+	case t1 == t2:
+		return t1, true
+	case IsAssignable(TypeFloat, t1) && IsAssignable(TypeFloat, t2):
+		return TypeFloat, true
+	case IsNumericType(t1) && IsNumericType(t2):
+		return TypeNumber, true
+	}
+
+	return TypeNone, false
+}
+
+// Symbol interface represents any type of
+// symbol.  A symbol is only a declaration; the
+// executor stores its values in the slot the
+// symbol was given within its scope
 type Symbol interface {
 	GetName() string
 	GetDataType() int
-	SetValue(value interface{})
-	AsString(indent string) string
-	ToString() string
+	GetScope() *SymbolTable
+	GetSlot() int
+	AsString(indent string, types *TypeTable) string
+
+	bind(scope *SymbolTable, slot int)
 }
 
-// NewSymbol ...
-func NewSymbol(name string, typeID int) Symbol {
+// NewSymbol creates a symbol of the given type,
+// looking up list, map and struct types in the table
+func NewSymbol(name string, typeID int, types *TypeTable) Symbol {
 	switch typeID {
-	case TypeNumber:
-		return NewNumberSymbol(name)
+	case TypeNumber, TypeInt, TypeFloat:
+		return NewNumberSymbol(name, typeID)
 	case TypeString:
 		return NewStringSymbol(name)
+	case TypeBool:
+		return NewBoolSymbol(name)
+	}
+
+	switch {
+	case types.IsListType(typeID):
+		return NewListSymbol(name, typeID)
+	case types.IsMapType(typeID):
+		return NewMapSymbol(name, typeID)
+	case types.IsStructType(typeID):
+		return NewStructSymbol(name, typeID)
 	default:
 		panic(fmt.Errorf("Attempt to create symbol with unrecognized type '%d'", typeID))
 	}
 }
 
-func formatSymbolAsString(s Symbol, indent string) string {
-	if len(s.ToString()) > 0 {
-		return indent + fmt.Sprintf("Symbol: %-20s  %-12s '%s'", s.GetName(), typeNames[s.GetDataType()], s.ToString())
-	}
-	return indent + fmt.Sprintf("Symbol: %-20s  %-12s", s.GetName(), typeNames[s.GetDataType()])
+// symbolSlot is embedded in each symbol to
+// record where its value is stored
+type symbolSlot struct {
+	scope *SymbolTable
+	slot  int
+}
+
+// GetScope returns the symbol table that declares this symbol
+func (s *symbolSlot) GetScope() *SymbolTable {
+	return s.scope
+}
+
+// GetSlot returns the index of this symbol's
+// value within its scope
+func (s *symbolSlot) GetSlot() int {
+	return s.slot
+}
+
+func (s *symbolSlot) bind(scope *SymbolTable, slot int) {
+	s.scope = scope
+	s.slot = slot
+}
+
+func formatSymbolAsString(s Symbol, indent string, types *TypeTable) string {
+	return indent + fmt.Sprintf("Symbol: %-20s  %-12s", s.GetName(), types.GetTypeName(s.GetDataType()))
 }
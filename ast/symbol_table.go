@@ -3,12 +3,13 @@ package ast
 // SymbolTable contains a table of symbols
 type SymbolTable struct {
 	symbols map[string]Symbol
+	slots   []Symbol
 	parent  *SymbolTable
 }
 
 // NewSymbolTable ...
 func NewSymbolTable(parent *SymbolTable) *SymbolTable {
-	return &SymbolTable{parent: parent, symbols: make(map[string]Symbol, 50)}
+	return &SymbolTable{parent: parent, symbols: make(map[string]Symbol, 50), slots: []Symbol{}}
 }
 
 // GetSymbols provides direct access to the internal map.
@@ -18,8 +19,23 @@ func (s *SymbolTable) GetSymbols() map[string]Symbol {
 	return s.symbols
 }
 
-// Add adds a symbol to the table
+// GetSlots returns the symbols in the order they
+// were added, which is also their slot order
+func (s *SymbolTable) GetSlots() []Symbol {
+	return s.slots
+}
+
+// GetParent returns the table of the enclosing
+// scope, or nil for the outermost scope
+func (s *SymbolTable) GetParent() *SymbolTable {
+	return s.parent
+}
+
+// Add adds a symbol to the table, assigning
+// it the next free slot
 func (s *SymbolTable) Add(name string, symbol Symbol) {
+	symbol.bind(s, len(s.slots))
+	s.slots = append(s.slots, symbol)
 	s.symbols[name] = symbol
 }
 
@@ -35,7 +51,7 @@ func (s *SymbolTable) Exists(name string) bool {
 		return s.parent.Exists(name)
 	}
 
-	return true
+	return s.ExistsLocal(name)
 }
 
 // GetLocal retrieves the symbol from the local symbol
@@ -61,7 +77,7 @@ func (s *SymbolTable) Get(name string) (Symbol, bool) {
 }
 
 // Delete removes a symbol from the local symbol
-// table only
+// table only.  Its slot is not reused.
 func (s *SymbolTable) Delete(name string) bool {
 	if s.ExistsLocal(name) {
 		delete(s.symbols, name)
@@ -0,0 +1,25 @@
+package ast
+
+// TargetValue stands for the current value of the
+// target of a compound assignment, so "a[i] += 1" is
+// "a[i] = <current a[i]> + 1".  The executor works out
+// where the target is once, and reads the value from
+// there, so the index is only evaluated once
+type TargetValue struct {
+	TargetNode Expression
+}
+
+// NewTargetValue ...
+func NewTargetValue(target Expression) *TargetValue {
+	return &TargetValue{TargetNode: target}
+}
+
+// GetDataType returns the type of the target
+func (t *TargetValue) GetDataType() int {
+	return t.TargetNode.GetDataType()
+}
+
+// AsString return the node as a string
+func (t *TargetValue) AsString(indent string, types *TypeTable) string {
+	return indent + "TargetValue : " + types.GetTypeName(t.GetDataType())
+}
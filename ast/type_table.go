@@ -0,0 +1,60 @@
+package ast
+
+// TypeTable holds the data types of a program: the
+// built-in types, and the list, map and struct types it
+// uses, which are created as they are first needed.  Each
+// program has its own table, held by the Program and
+// passed to whatever needs to look its types up, so the
+// types declared by one program are never seen by another
+type TypeTable struct {
+	names []string
+
+	// listTypes maps an element type to the data type
+	// of a list of that element, and elementTypes maps
+	// back again
+	listTypes    map[int]int
+	elementTypes map[int]int
+
+	// mapTypes maps a value type to the data type of a
+	// map from strings to that value, and valueTypes
+	// maps back again
+	mapTypes   map[int]int
+	valueTypes map[int]int
+
+	// structTypes maps the name of a struct type to its
+	// data type, and structDeclarations maps the data
+	// type to its declaration
+	structTypes        map[string]int
+	structDeclarations map[int]*StructDeclaration
+}
+
+// NewTypeTable creates the table of types for
+// a new program
+func NewTypeTable() *TypeTable {
+	return &TypeTable{
+		names:              append([]string{}, typeNames...),
+		listTypes:          map[int]int{TypeString: TypeStringList},
+		elementTypes:       map[int]int{TypeStringList: TypeString},
+		mapTypes:           map[int]int{},
+		valueTypes:         map[int]int{},
+		structTypes:        map[string]int{},
+		structDeclarations: map[int]*StructDeclaration{},
+	}
+}
+
+// GetTypeName returns the name of a data
+// type as it is written in a program
+func (t *TypeTable) GetTypeName(dataType int) string {
+	if dataType >= 0 && dataType < len(t.names) {
+		return t.names[dataType]
+	}
+
+	return "unknown"
+}
+
+// newType adds a type with the given
+// name, returning its data type
+func (t *TypeTable) newType(name string) int {
+	t.names = append(t.names, name)
+	return len(t.names) - 1
+}
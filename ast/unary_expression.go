@@ -0,0 +1,52 @@
+package ast
+
+import "fmt"
+
+// UnaryExpression applies an operator
+// to a single operand
+type UnaryExpression struct {
+	Operator    int
+	OperandNode Expression
+
+	// DataType is the type of the result,
+	// filled in by the checker
+	DataType int
+
+	Line int
+	Col  int
+}
+
+// NewUnaryExpression ...
+func NewUnaryExpression(operator int, operand Expression, line int, col int) *UnaryExpression {
+	return &UnaryExpression{Operator: operator, OperandNode: operand, DataType: TypeNone, Line: line, Col: col}
+}
+
+// GetDataType returns the type of the result
+func (u *UnaryExpression) GetDataType() int {
+	return u.DataType
+}
+
+// AsString return the node as a string
+func (u *UnaryExpression) AsString(indent string, types *TypeTable) string {
+	result := indent + fmt.Sprintf("UnaryExpression '%s' : %s", GetOperatorSymbol(u.Operator), types.GetTypeName(u.DataType))
+	result += "\n" + u.OperandNode.AsString("  "+indent, types)
+	return result
+}
+
+// UnaryResultType returns the type of applying the
+// operator to an operand of the given type, and false
+// if the operator does not accept that type
+func UnaryResultType(operator int, operand int) (int, bool) {
+	switch operator {
+	case NotOperator:
+		if operand == TypeBool {
+			return TypeBool, true
+		}
+	case MinusOperator, PlusOperator:
+		if IsNumericType(operand) {
+			return operand, true
+		}
+	}
+
+	return TypeNone, false
+}
@@ -1,22 +1,26 @@
 package ast
 
-// VarStatement is a "println" statement
+// VarStatement declares a variable, with
+// an optional initial value
 type VarStatement struct {
 	SymbolNode     Symbol
 	ExpressionNode Expression
+
+	Line int
+	Col  int
 }
 
 // NewVarStatement ...
-func NewVarStatement(name string, typeID int) *VarStatement {
-	return &VarStatement{SymbolNode: NewSymbol(name, typeID)}
+func NewVarStatement(symbol Symbol, line int, col int) *VarStatement {
+	return &VarStatement{SymbolNode: symbol, Line: line, Col: col}
 }
 
 // AsString return the node as a string
-func (s VarStatement) AsString(indent string) string {
-	result := indent + "VarStatement : " + s.SymbolNode.AsString("")
+func (s VarStatement) AsString(indent string, types *TypeTable) string {
+	result := indent + "VarStatement : " + s.SymbolNode.AsString("", types)
 
 	if s.ExpressionNode != nil {
-		result += "\n" + "  " + indent + "=\n" + s.ExpressionNode.AsString("  "+indent)
+		result += "\n" + "  " + indent + "=\n" + s.ExpressionNode.AsString("  "+indent, types)
 	}
 
 	return result
@@ -0,0 +1,431 @@
+// Package checker performs the semantic analysis of a
+// parsed program.  It walks the AST after parsing,
+// declaring each variable in the scope of its block,
+// resolving names to the variables and functions they
+// refer to, and working out and checking the type of
+// every expression.
+//
+// All functions and struct types are declared before
+// any of their bodies are checked, so a function may
+// call any other, and use any type, wherever it appears
+// in the file.
+package checker
+
+import (
+	"fmt"
+
+	"github.com/hculpan/kablang/ast"
+	"github.com/hculpan/kablang/system"
+)
+
+// Checker holds the state of the
+// analysis of a single program
+type Checker struct {
+	errors     []error
+	warnings   []error
+	blockStack *ast.BlockStack
+
+	// WarnShadowing reports a warning for each variable
+	// that hides one declared in an enclosing scope
+	WarnShadowing bool
+
+	functions       map[string]*ast.Function
+	currentFunction *ast.Function
+
+	// types holds the data types of the
+	// program being checked
+	types *ast.TypeTable
+}
+
+// NewChecker ...
+func NewChecker() Checker {
+	return Checker{blockStack: ast.NewBlockStack(), functions: map[string]*ast.Function{}}
+}
+
+// Check analyses the program, completing the AST
+// with the symbols and types it resolves.  It returns
+// every error found, each with its position
+func (c *Checker) Check(program *ast.Program) []error {
+	c.errors = []error{}
+	c.warnings = []error{}
+
+	if program == nil || program.BlockNode == nil {
+		c.addError(fmt.Errorf("Invalid program"))
+		return c.errors
+	}
+
+	c.types = program.Types
+
+	c.blockStack.Push(program.BlockNode)
+	defer c.blockStack.Pop()
+
+	c.checkStructs(program.Structs)
+
+	if len(program.Functions) == 0 {
+		c.checkStatements(program.BlockNode)
+		return c.errors
+	}
+
+	for _, f := range program.Functions {
+		if _, exists := c.functions[f.Name]; exists {
+			c.addError(fmt.Errorf("Redefinition of function '%s' at %d:%d", f.Name, f.Line, f.Col))
+			continue
+		}
+		c.functions[f.Name] = f
+	}
+
+	if main, exists := c.functions["main"]; !exists {
+		c.addError(fmt.Errorf("No main() function declared"))
+	} else if len(main.Parameters) > 0 || main.ReturnDataType != ast.TypeNone {
+		c.addError(fmt.Errorf("Function main() cannot have parameters or a return type at %d:%d", main.Line, main.Col))
+	}
+
+	for _, f := range program.Functions {
+		c.checkFunction(f)
+	}
+
+	return c.errors
+}
+
+// checkStructs declares the struct types, then checks
+// their fields.  A struct can't contain a field of its
+// own type, even through another struct, as it would
+// never end, although it can hold a list of its own type
+func (c *Checker) checkStructs(structs []*ast.StructDeclaration) {
+	declared := map[string]bool{}
+	for _, s := range structs {
+		if declared[s.Name] {
+			c.addError(fmt.Errorf("Redefinition of type '%s' at %d:%d", s.Name, s.Line, s.Col))
+			continue
+		}
+		declared[s.Name] = true
+		c.types.DeclareStruct(s)
+	}
+
+	for _, s := range structs {
+		names := map[string]bool{}
+		for _, f := range s.Fields {
+			if names[f.Name] {
+				c.addError(fmt.Errorf("Duplicate field '%s' in type '%s' at %d:%d", f.Name, s.Name, s.Line, s.Col))
+			}
+			names[f.Name] = true
+			c.checkDataType(f.DataType, s.Line, s.Col)
+		}
+
+		if c.containsStruct(s, s.DataType, map[int]bool{}) {
+			c.addError(fmt.Errorf("Type '%s' cannot contain itself at %d:%d", s.Name, s.Line, s.Col))
+		}
+	}
+}
+
+// containsStruct checks whether a struct has a field
+// of the target type, directly or in one of its fields
+func (c *Checker) containsStruct(s *ast.StructDeclaration, target int, visited map[int]bool) bool {
+	for _, f := range s.Fields {
+		if f.DataType == target {
+			return true
+		}
+
+		fieldStruct := c.types.GetStructDeclaration(f.DataType)
+		if fieldStruct != nil && !visited[f.DataType] {
+			visited[f.DataType] = true
+			if c.containsStruct(fieldStruct, target, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkDataType checks that any struct type named
+// in a data type, including as the element type of
+// a list or map, has been declared
+func (c *Checker) checkDataType(dataType int, line int, col int) bool {
+	switch {
+	case c.types.IsListType(dataType):
+		return c.checkDataType(c.types.ElementType(dataType), line, col)
+	case c.types.IsMapType(dataType):
+		return c.checkDataType(c.types.ValueType(dataType), line, col)
+	case c.types.IsStructType(dataType) && c.types.GetStructDeclaration(dataType) == nil:
+		c.addError(fmt.Errorf("Undeclared type '%s' at %d:%d", c.types.GetTypeName(dataType), line, col))
+		return false
+	}
+
+	return true
+}
+
+func (c *Checker) checkFunction(f *ast.Function) {
+	c.checkDataType(f.ReturnDataType, f.Line, f.Col)
+
+	// The parameters take the first slots of
+	// the function's block, in order
+	for _, param := range f.Parameters {
+		c.checkDataType(param.DataType, f.Line, f.Col)
+		if f.BlockNode.Symbols.ExistsLocal(param.Name) {
+			c.addError(fmt.Errorf("Duplicate parameter '%s' in function '%s' at %d:%d", param.Name, f.Name, f.Line, f.Col))
+		}
+		f.BlockNode.AddSymbol(ast.NewSymbol(param.Name, param.DataType, c.types))
+	}
+
+	c.currentFunction = f
+	c.checkBlock(f.BlockNode)
+	c.currentFunction = nil
+}
+
+func (c *Checker) checkBlock(block *ast.Block) {
+	c.blockStack.Push(block)
+	c.checkStatements(block)
+	c.blockStack.Pop()
+}
+
+func (c *Checker) checkStatements(block *ast.Block) {
+	if block.StatementsNode == nil {
+		return
+	}
+
+	for _, s := range block.StatementsNode.StatementListNode {
+		c.checkStatement(s)
+	}
+}
+
+func (c *Checker) checkStatement(s ast.Statement) {
+	switch s.(type) {
+	case *ast.VarStatement:
+		c.checkVar(s.(*ast.VarStatement))
+	case *ast.AssignStatement:
+		c.checkAssignment(s.(*ast.AssignStatement))
+	case *ast.PrintStatement:
+		if s.(*ast.PrintStatement).ExpressionNode != nil {
+			c.checkExpression(s.(*ast.PrintStatement).ExpressionNode)
+		}
+	case *ast.Block:
+		c.checkBlock(s.(*ast.Block))
+	case *ast.IfStatement:
+		c.checkIf(s.(*ast.IfStatement))
+	case *ast.ForStatement:
+		c.checkFor(s.(*ast.ForStatement))
+	case *ast.ForInStatement:
+		c.checkForIn(s.(*ast.ForInStatement))
+	case *ast.ReturnStatement:
+		c.checkReturn(s.(*ast.ReturnStatement))
+	case *ast.CallExpression:
+		c.checkCall(s.(*ast.CallExpression))
+	}
+}
+
+// Warnings returns the warnings found by the last
+// call to Check.  Warnings don't stop a program running
+func (c *Checker) Warnings() []error {
+	return c.warnings
+}
+
+// checkVar checks the initial value before declaring
+// the variable, so "var x number = x" does not refer
+// to the variable being declared
+func (c *Checker) checkVar(s *ast.VarStatement) {
+	if c.checkDataType(s.SymbolNode.GetDataType(), s.Line, s.Col) && s.ExpressionNode != nil {
+		s.ExpressionNode = c.expectType(s.SymbolNode.GetDataType(), s.ExpressionNode, s.Line, s.Col)
+	}
+
+	c.declare(s.SymbolNode, s.Line, s.Col)
+}
+
+// declare adds a variable to the current block,
+// reporting an error if the block already has one
+// of that name
+func (c *Checker) declare(symbol ast.Symbol, line int, col int) {
+	name := symbol.GetName()
+	symbols := c.currentBlock().Symbols
+	if symbols.ExistsLocal(name) {
+		c.addError(fmt.Errorf("Redefinition of variable '%s' at %d:%d", name, line, col))
+		return
+	}
+
+	if c.WarnShadowing && symbols.GetParent() != nil && symbols.GetParent().Exists(name) {
+		c.addWarning(fmt.Errorf("Variable '%s' at %d:%d shadows a variable declared in an enclosing scope", name, line, col))
+	}
+
+	c.currentBlock().AddSymbol(symbol)
+}
+
+// checkAssignment resolves the variable being assigned to
+// in the same way as one being read, so a block can assign
+// to the variables of the blocks that enclose it
+func (c *Checker) checkAssignment(s *ast.AssignStatement) {
+	var dataType int
+	var target string
+
+	switch s.TargetNode.(type) {
+	case *ast.Identifier:
+		v := s.TargetNode.(*ast.Identifier)
+		symbol, exists := c.currentBlock().Symbols.Get(v.Name)
+		if !exists {
+			c.addError(fmt.Errorf("Assignment without declaration for variable '%s' at line %d:%d", v.Name, v.Line, v.Col))
+			return
+		}
+		v.SymbolNode = symbol
+		dataType, target = symbol.GetDataType(), fmt.Sprintf("variable '%s'", v.Name)
+	case *ast.IndexExpression:
+		if dataType = c.checkExpression(s.TargetNode); dataType == ast.TypeNone {
+			return
+		}
+		target = "list element"
+		if c.types.IsMapType(s.TargetNode.(*ast.IndexExpression).CollectionNode.GetDataType()) {
+			target = "map value"
+		}
+	case *ast.FieldExpression:
+		if dataType = c.checkExpression(s.TargetNode); dataType == ast.TypeNone {
+			return
+		}
+		target = fmt.Sprintf("field '%s'", s.TargetNode.(*ast.FieldExpression).Name)
+	default:
+		c.addError(fmt.Errorf("Cannot assign to this expression at line %d:%d", s.Line, s.Col))
+		return
+	}
+
+	// Compound assignments work on numbers, and
+	// += also appends to a string
+	if s.Kind != ast.PlainAssignment && !ast.IsNumericType(dataType) &&
+		!(dataType == ast.TypeString && s.OperatorSymbol() == "+=") {
+		c.addError(fmt.Errorf("Operator '%s' not supported for %s of type %s at line %d:%d",
+			s.OperatorSymbol(), target, c.types.GetTypeName(dataType), s.Line, s.Col))
+		return
+	}
+
+	// / always gives a float, so /= can't be used on an
+	// int; "x = x // y" is the integer division
+	if dataType == ast.TypeInt && s.OperatorSymbol() == "/=" {
+		c.addError(fmt.Errorf("Operator '/=' gives a float, which cannot be assigned to %s of type int; use // for integer division at line %d:%d",
+			target, s.Line, s.Col))
+		return
+	}
+
+	s.ExpressionNode = c.expectType(dataType, s.ExpressionNode, s.Line, s.Col)
+}
+
+func (c *Checker) checkIf(s *ast.IfStatement) {
+	s.ConditionNode = c.expectType(ast.TypeBool, s.ConditionNode, s.Line, s.Col)
+	c.checkBlock(s.BlockNode)
+
+	switch s.ElseNode.(type) {
+	case *ast.IfStatement:
+		c.checkIf(s.ElseNode.(*ast.IfStatement))
+	case *ast.Block:
+		c.checkBlock(s.ElseNode.(*ast.Block))
+	}
+}
+
+func (c *Checker) checkFor(s *ast.ForStatement) {
+	c.blockStack.Push(s.ScopeNode)
+	defer c.blockStack.Pop()
+
+	if s.InitNode != nil {
+		c.checkStatement(s.InitNode)
+	}
+
+	if s.ConditionNode != nil {
+		s.ConditionNode = c.expectType(ast.TypeBool, s.ConditionNode, s.Line, s.Col)
+	}
+
+	if s.StepNode != nil {
+		c.checkStatement(s.StepNode)
+	}
+
+	c.checkBlock(s.BlockNode)
+}
+
+// checkForIn declares the loop variables with the
+// types of the keys and values of the collection
+func (c *Checker) checkForIn(s *ast.ForInStatement) {
+	collectionType := c.checkExpression(s.CollectionNode)
+	if collectionType == ast.TypeNone {
+		return
+	}
+
+	keyType, valueType, ok := c.types.IterationTypes(collectionType)
+	if !ok {
+		c.addError(fmt.Errorf("Cannot loop over a value of type %s at line %d:%d",
+			c.types.GetTypeName(collectionType), s.Line, s.Col))
+		return
+	}
+
+	c.blockStack.Push(s.ScopeNode)
+	defer c.blockStack.Pop()
+
+	if s.KeyName != "" {
+		s.KeySymbol = ast.NewSymbol(s.KeyName, keyType, c.types)
+		c.declare(s.KeySymbol, s.Line, s.Col)
+	}
+	s.ValueSymbol = ast.NewSymbol(s.ValueName, valueType, c.types)
+	c.declare(s.ValueSymbol, s.Line, s.Col)
+
+	c.checkBlock(s.BlockNode)
+}
+
+func (c *Checker) checkReturn(s *ast.ReturnStatement) {
+	f := c.currentFunction
+	if f == nil {
+		c.addError(fmt.Errorf("'return' outside of function at line %d:%d", s.Line, s.Col))
+		return
+	}
+	s.DataType = f.ReturnDataType
+
+	switch {
+	case s.ExpressionNode == nil && s.DataType != ast.TypeNone:
+		c.addError(fmt.Errorf("Function '%s' must return a value of type %s at line %d:%d",
+			f.Name, c.types.GetTypeName(s.DataType), s.Line, s.Col))
+	case s.ExpressionNode != nil && s.DataType == ast.TypeNone:
+		c.addError(fmt.Errorf("Function '%s' does not return a value at line %d:%d", f.Name, s.Line, s.Col))
+	case s.ExpressionNode != nil:
+		s.ExpressionNode = c.expectType(s.DataType, s.ExpressionNode, s.Line, s.Col)
+	}
+}
+
+// expectType checks an expression against the type
+// expected where it is used, returning the expression
+// with an int widened to a float if that is needed.
+// An expression with an error has no type, and since
+// that error has already been reported it is not
+// reported again
+func (c *Checker) expectType(expected int, exp ast.Expression, line int, col int) ast.Expression {
+	found := c.checkExpressionAs(expected, exp)
+	if found != ast.TypeNone && !ast.IsAssignable(expected, found) {
+		c.addError(fmt.Errorf("Expected %s expression, found %s expression at line %d:%d",
+			c.types.GetTypeName(expected), c.types.GetTypeName(found), line, col))
+	}
+
+	return widen(expected, found, exp)
+}
+
+// widen converts an int expression used
+// where a float is expected
+func widen(expected int, found int, exp ast.Expression) ast.Expression {
+	if expected == ast.TypeFloat && found == ast.TypeInt {
+		return ast.NewCastExpression(ast.TypeFloat, exp, 0, 0)
+	}
+
+	return exp
+}
+
+// lookupFunction finds the named function, looking first
+// at those declared in the program, then the built-ins
+func (c *Checker) lookupFunction(name string) (*ast.Function, bool) {
+	if f, exists := c.functions[name]; exists {
+		return f, true
+	}
+
+	return system.GetSystemFunction(name)
+}
+
+func (c *Checker) currentBlock() *ast.Block {
+	return c.blockStack.Peek()
+}
+
+func (c *Checker) addError(e error) {
+	c.errors = append(c.errors, e)
+}
+
+func (c *Checker) addWarning(e error) {
+	c.warnings = append(c.warnings, e)
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/hculpan/kablang/parser"
+)
+
+// check parses and checks a program, returning
+// any errors from either
+func check(t *testing.T, lines ...string) []error {
+	p := parser.NewParser()
+	program, errs := p.Parse(lines)
+	if len(errs) != 0 {
+		t.Log(errs)
+		t.FailNow()
+	}
+
+	c := NewChecker()
+	return c.Check(program)
+}
+
+func TestCheckerMathIntResults(t *testing.T) {
+	errs := check(t, "{",
+		"var a int = abs(-3)",
+		"var b int = min(1, 2)",
+		"var c int = max(a, b)",
+		"}")
+	if len(errs) != 0 {
+		t.Log(errs)
+		t.Fail()
+	}
+}
+
+func TestCheckerMathFloatResults(t *testing.T) {
+	errs := check(t, "{",
+		"var a float = abs(-2.5)",
+		"var b float = max(1.5, 2)",
+		"var c float = min(2, a)",
+		"var d float = pow(2, 0.5)",
+		"}")
+	if len(errs) != 0 {
+		t.Log(errs)
+		t.Fail()
+	}
+}
+
+func TestCheckerMathMixedResultIsNotInt(t *testing.T) {
+	errs := check(t, "{",
+		"var a int = max(1.5, 2)",
+		"}")
+	if len(errs) != 1 {
+		t.Logf("Expected 1 error, found %d: %v", len(errs), errs)
+		t.Fail()
+	}
+}
+
+func TestCheckerTypesPerProgram(t *testing.T) {
+	p1, p2 := parser.NewParser(), parser.NewParser()
+	program1, errs1 := p1.Parse([]string{"type Point struct {", "x int", "}", "func main() {", "var p Point", "}"})
+	program2, errs2 := p2.Parse([]string{"func main() {", "var l []int", "var p Point", "}"})
+	if len(errs1) != 0 || len(errs2) != 0 {
+		t.Log(errs1, errs2)
+		t.FailNow()
+	}
+
+	// Checking in the other order shows that neither
+	// program sees the types of the other
+	c2 := NewChecker()
+	if errs := c2.Check(program2); len(errs) != 1 {
+		t.Logf("Expected 1 error, found %d: %v", len(errs), errs)
+		t.Fail()
+	}
+
+	c1 := NewChecker()
+	if errs := c1.Check(program1); len(errs) != 0 {
+		t.Log(errs)
+		t.Fail()
+	}
+}
@@ -0,0 +1,392 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// checkExpression resolves the names used in an
+// expression and works out its type, which it returns.
+// The type is TypeNone if the expression has an error
+func (c *Checker) checkExpression(exp ast.Expression) int {
+	switch exp.(type) {
+	case *ast.Identifier:
+		c.checkIdentifier(exp.(*ast.Identifier))
+	case *ast.CallExpression:
+		call := exp.(*ast.CallExpression)
+		if c.checkCall(call) && call.DataType == ast.TypeNone {
+			c.addError(fmt.Errorf("Function '%s' does not return a value at %d:%d", call.Name, call.Line, call.Col))
+			return ast.TypeNone
+		}
+	case *ast.BinaryExpression:
+		c.checkBinaryExpression(exp.(*ast.BinaryExpression))
+	case *ast.UnaryExpression:
+		c.checkUnaryExpression(exp.(*ast.UnaryExpression))
+	case *ast.CastExpression:
+		return c.checkCast(exp.(*ast.CastExpression))
+	case *ast.ListLiteral:
+		c.checkListLiteral(exp.(*ast.ListLiteral))
+	case *ast.MapLiteral:
+		c.checkMapLiteral(exp.(*ast.MapLiteral))
+	case *ast.StructLiteral:
+		c.checkStructLiteral(exp.(*ast.StructLiteral))
+	case *ast.InterpolatedString:
+		// Any type of value can be converted to a string
+		for _, part := range exp.(*ast.InterpolatedString).PartNodes {
+			c.checkExpression(part)
+		}
+	case *ast.IndexExpression:
+		c.checkIndex(exp.(*ast.IndexExpression))
+	case *ast.FieldExpression:
+		c.checkField(exp.(*ast.FieldExpression))
+	case *ast.SliceExpression:
+		return c.checkSlice(exp.(*ast.SliceExpression))
+	}
+
+	return exp.GetDataType()
+}
+
+// checkExpressionAs checks an expression used where a
+// value of the expected type is needed.  A list or map
+// literal takes its type from there, so that [] can be
+// an empty list of any type and [1, 2] can be a list
+// of floats
+func (c *Checker) checkExpressionAs(expected int, exp ast.Expression) int {
+	switch exp.(type) {
+	case *ast.ListLiteral:
+		if c.types.IsListType(expected) {
+			exp.(*ast.ListLiteral).DataType = expected
+		}
+	case *ast.MapLiteral:
+		if c.types.IsMapType(expected) {
+			exp.(*ast.MapLiteral).DataType = expected
+		}
+	}
+
+	return c.checkExpression(exp)
+}
+
+func (c *Checker) checkIdentifier(i *ast.Identifier) {
+	if symbol, exists := c.currentBlock().Symbols.Get(i.Name); exists {
+		i.SymbolNode = symbol
+	} else {
+		c.addError(fmt.Errorf("Undeclared variable '%s' at %d:%d", i.Name, i.Line, i.Col))
+	}
+}
+
+func (c *Checker) checkBinaryExpression(b *ast.BinaryExpression) {
+	left := c.checkExpression(b.LeftNode)
+	right := c.checkExpression(b.RightNode)
+	if left == ast.TypeNone || right == ast.TypeNone {
+		return
+	}
+
+	dataType, ok := ast.BinaryResultType(b.Operator, left, right)
+	if !ok {
+		c.addError(fmt.Errorf("Operator '%s' not defined for %s and %s at line %d:%d",
+			ast.GetOperatorSymbol(b.Operator), c.types.GetTypeName(left), c.types.GetTypeName(right), b.Line, b.Col))
+		return
+	}
+	b.DataType = dataType
+}
+
+func (c *Checker) checkUnaryExpression(u *ast.UnaryExpression) {
+	operand := c.checkExpression(u.OperandNode)
+	if operand == ast.TypeNone {
+		return
+	}
+
+	dataType, ok := ast.UnaryResultType(u.Operator, operand)
+	if !ok {
+		c.addError(fmt.Errorf("Operator '%s' not defined for %s at line %d:%d",
+			ast.GetOperatorSymbol(u.Operator), c.types.GetTypeName(operand), u.Line, u.Col))
+		return
+	}
+	u.DataType = dataType
+}
+
+// checkCast checks that a cast converts a number
+func (c *Checker) checkCast(cast *ast.CastExpression) int {
+	operand := c.checkExpression(cast.ExpressionNode)
+	if operand == ast.TypeNone {
+		return ast.TypeNone
+	}
+
+	if !ast.IsNumericType(operand) {
+		c.addError(fmt.Errorf("Cannot convert %s to %s at line %d:%d",
+			c.types.GetTypeName(operand), c.types.GetTypeName(cast.DataType), cast.Line, cast.Col))
+		return ast.TypeNone
+	}
+
+	return cast.DataType
+}
+
+// checkListLiteral checks the elements of a list.  If
+// the type of the list is not already known from where
+// it is used, it is worked out from the elements, which
+// must have a common type
+func (c *Checker) checkListLiteral(l *ast.ListLiteral) {
+	if l.DataType != ast.TypeNone {
+		elementType := c.types.ElementType(l.DataType)
+		for i, e := range l.ElementNodes {
+			l.ElementNodes[i] = c.expectType(elementType, e, l.Line, l.Col)
+		}
+		return
+	}
+
+	if len(l.ElementNodes) == 0 {
+		c.addError(fmt.Errorf("Cannot work out the type of an empty list at line %d:%d", l.Line, l.Col))
+		return
+	}
+
+	elementType := ast.TypeNone
+	types := make([]int, len(l.ElementNodes))
+	for i, e := range l.ElementNodes {
+		if types[i] = c.checkExpression(e); types[i] == ast.TypeNone {
+			return
+		}
+
+		if i == 0 {
+			elementType = types[i]
+		} else if common, ok := ast.CommonType(elementType, types[i]); ok {
+			elementType = common
+		} else {
+			c.addError(fmt.Errorf("List elements of type %s and %s cannot be mixed at line %d:%d",
+				c.types.GetTypeName(elementType), c.types.GetTypeName(types[i]), l.Line, l.Col))
+			return
+		}
+	}
+
+	for i, e := range l.ElementNodes {
+		l.ElementNodes[i] = widen(elementType, types[i], e)
+	}
+	l.DataType = c.types.ListType(elementType)
+}
+
+// checkMapLiteral checks the entries of a map in the
+// same way as the elements of a list.  The keys must
+// all be strings
+func (c *Checker) checkMapLiteral(m *ast.MapLiteral) {
+	for i, k := range m.KeyNodes {
+		m.KeyNodes[i] = c.expectType(ast.TypeString, k, m.Line, m.Col)
+	}
+
+	if m.DataType != ast.TypeNone {
+		valueType := c.types.ValueType(m.DataType)
+		for i, v := range m.ValueNodes {
+			m.ValueNodes[i] = c.expectType(valueType, v, m.Line, m.Col)
+		}
+		return
+	}
+
+	if len(m.ValueNodes) == 0 {
+		c.addError(fmt.Errorf("Cannot work out the type of an empty map at line %d:%d", m.Line, m.Col))
+		return
+	}
+
+	valueType := ast.TypeNone
+	types := make([]int, len(m.ValueNodes))
+	for i, v := range m.ValueNodes {
+		if types[i] = c.checkExpression(v); types[i] == ast.TypeNone {
+			return
+		}
+
+		if i == 0 {
+			valueType = types[i]
+		} else if common, ok := ast.CommonType(valueType, types[i]); ok {
+			valueType = common
+		} else {
+			c.addError(fmt.Errorf("Map values of type %s and %s cannot be mixed at line %d:%d",
+				c.types.GetTypeName(valueType), c.types.GetTypeName(types[i]), m.Line, m.Col))
+			return
+		}
+	}
+
+	for i, v := range m.ValueNodes {
+		m.ValueNodes[i] = widen(valueType, types[i], v)
+	}
+	m.DataType = c.types.MapType(valueType)
+}
+
+// checkStructLiteral checks that each field given a
+// value is declared once in the struct's type, and
+// that the value is of the field's type
+func (c *Checker) checkStructLiteral(l *ast.StructLiteral) {
+	s := c.types.GetStructDeclaration(l.DataType)
+	if s == nil {
+		c.addError(fmt.Errorf("Undeclared type '%s' at line %d:%d", c.types.GetTypeName(l.DataType), l.Line, l.Col))
+		l.DataType = ast.TypeNone
+		return
+	}
+
+	l.FieldIndexes = make([]int, len(l.FieldNames))
+	given := map[string]bool{}
+	for i, name := range l.FieldNames {
+		index := s.FieldIndex(name)
+		switch {
+		case index < 0:
+			c.addError(fmt.Errorf("Type '%s' has no field '%s' at line %d:%d", s.Name, name, l.Line, l.Col))
+			c.checkExpression(l.ValueNodes[i])
+		case given[name]:
+			c.addError(fmt.Errorf("Field '%s' given more than once at line %d:%d", name, l.Line, l.Col))
+		default:
+			l.ValueNodes[i] = c.expectType(s.Fields[index].DataType, l.ValueNodes[i], l.Line, l.Col)
+		}
+		l.FieldIndexes[i] = index
+		given[name] = true
+	}
+}
+
+// checkField checks that a field is
+// declared in the type of the struct
+func (c *Checker) checkField(f *ast.FieldExpression) {
+	structType := c.checkExpression(f.StructNode)
+	if structType == ast.TypeNone {
+		return
+	}
+
+	// An undeclared struct type has already been reported
+	s := c.types.GetStructDeclaration(structType)
+	if s == nil {
+		if !c.types.IsStructType(structType) {
+			c.addError(fmt.Errorf("Cannot read field '%s' of a value of type %s at line %d:%d",
+				f.Name, c.types.GetTypeName(structType), f.Line, f.Col))
+		}
+		return
+	}
+
+	if f.Index = s.FieldIndex(f.Name); f.Index < 0 {
+		c.addError(fmt.Errorf("Type '%s' has no field '%s' at line %d:%d", s.Name, f.Name, f.Line, f.Col))
+		return
+	}
+	f.DataType = s.Fields[f.Index].DataType
+}
+
+// checkIndex checks an index into a list, or
+// a key used to look up a value in a map
+func (c *Checker) checkIndex(i *ast.IndexExpression) {
+	collectionType := c.checkExpression(i.CollectionNode)
+	if !c.types.IsMapType(collectionType) {
+		if c.checkListAndIndex(collectionType, i.IndexNode, i.Line, i.Col) {
+			i.DataType = c.types.ElementType(collectionType)
+		}
+		return
+	}
+
+	if keyType := c.checkExpression(i.IndexNode); keyType == ast.TypeNone {
+		return
+	} else if keyType != ast.TypeString {
+		c.addError(fmt.Errorf("Map key must be a string, found %s at line %d:%d", c.types.GetTypeName(keyType), i.Line, i.Col))
+		return
+	}
+
+	i.DataType = c.types.ValueType(collectionType)
+}
+
+func (c *Checker) checkSlice(s *ast.SliceExpression) int {
+	listType := c.checkExpression(s.ListNode)
+	if listType != ast.TypeNone && !c.types.IsListType(listType) {
+		c.addError(fmt.Errorf("Cannot slice a value of type %s at line %d:%d", c.types.GetTypeName(listType), s.Line, s.Col))
+		return ast.TypeNone
+	}
+
+	ok := c.checkListAndIndex(listType, s.LowNode, s.Line, s.Col)
+	if !c.checkListAndIndex(listType, s.HighNode, s.Line, s.Col) || !ok {
+		return ast.TypeNone
+	}
+
+	return listType
+}
+
+// checkListAndIndex checks that a list is being indexed,
+// and that the index, if there is one, is a whole number.
+// An index of type number is checked when it is used
+func (c *Checker) checkListAndIndex(listType int, index ast.Expression, line int, col int) bool {
+	indexType := ast.TypeInt
+	if index != nil {
+		indexType = c.checkExpression(index)
+	}
+
+	switch {
+	case listType == ast.TypeNone || indexType == ast.TypeNone:
+		return false
+	case !c.types.IsListType(listType):
+		c.addError(fmt.Errorf("Cannot index a value of type %s at line %d:%d", c.types.GetTypeName(listType), line, col))
+		return false
+	case indexType != ast.TypeInt && indexType != ast.TypeNumber:
+		c.addError(fmt.Errorf("List index must be an int, found %s at line %d:%d", c.types.GetTypeName(indexType), line, col))
+		return false
+	}
+
+	return true
+}
+
+// checkCall resolves the function being called and
+// checks its arguments against the parameters.  It
+// returns false if the function can't be resolved,
+// or the type of its result can't be worked out
+func (c *Checker) checkCall(call *ast.CallExpression) bool {
+	f, exists := c.lookupFunction(call.Name)
+	if !exists {
+		c.addError(fmt.Errorf("Undeclared function '%s' at %d:%d", call.Name, call.Line, call.Col))
+		for _, a := range call.ArgumentNodes {
+			c.checkExpression(a)
+		}
+		return false
+	}
+	call.FunctionNode = f
+
+	params, returnType := f.Parameters, f.ReturnDataType
+	argTypes := make([]int, len(call.ArgumentNodes))
+	for i, a := range call.ArgumentNodes {
+		if f.ResolveTypes == nil && i < len(params) {
+			argTypes[i] = c.checkExpressionAs(params[i].DataType, a)
+		} else {
+			argTypes[i] = c.checkExpression(a)
+		}
+	}
+
+	if f.ResolveTypes != nil {
+		for _, t := range argTypes {
+			if t == ast.TypeNone {
+				return false
+			}
+		}
+
+		var ok bool
+		if params, returnType, ok = f.ResolveTypes(c.types, argTypes); !ok {
+			names := make([]string, len(argTypes))
+			for i, t := range argTypes {
+				names[i] = c.types.GetTypeName(t)
+			}
+			c.addError(fmt.Errorf("Cannot call %s with arguments (%s) at %d:%d",
+				f.Signature(c.types), strings.Join(names, ", "), call.Line, call.Col))
+			return false
+		}
+	}
+
+	switch {
+	case len(call.ArgumentNodes) < len(params):
+		c.addError(fmt.Errorf("Not enough arguments in call to %s at %d:%d", f.Signature(c.types), call.Line, call.Col))
+	case len(call.ArgumentNodes) > len(params):
+		c.addError(fmt.Errorf("Too many arguments in call to %s at %d:%d", f.Signature(c.types), call.Line, call.Col))
+	}
+
+	for i, a := range call.ArgumentNodes {
+		if i >= len(params) || argTypes[i] == ast.TypeNone {
+			continue
+		}
+
+		param := params[i]
+		if !ast.IsAssignable(param.DataType, argTypes[i]) {
+			c.addError(fmt.Errorf("Argument '%s' in call to %s must be %s, found %s at %d:%d", param.Name,
+				f.Signature(c.types), c.types.GetTypeName(param.DataType), c.types.GetTypeName(argTypes[i]), call.Line, call.Col))
+		}
+		call.ArgumentNodes[i] = widen(param.DataType, argTypes[i], a)
+	}
+
+	call.DataType = returnType
+	return true
+}
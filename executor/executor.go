@@ -2,6 +2,7 @@ package executor
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/hculpan/kablang/ast"
 )
@@ -10,28 +11,54 @@ import (
 // environment for this interpreter
 type Executor struct {
 	Errors []error
-	blocks *ast.BlockStack
+
+	// Filename is the source file, used
+	// in the positions of runtime errors
+	Filename string
+
+	// calls is the stack of active calls, each
+	// holding the position that call has reached
+	calls []*StackEntry
+
+	// frame holds the variables of the block currently
+	// executing, with globals holding those of the
+	// top-level block
+	frame   *frame
+	globals *frame
+
+	// types holds the data types of the
+	// program being run
+	types *ast.TypeTable
+
+	// returnValue holds the result of the most
+	// recent return statement until the caller
+	// picks it up
+	returnValue interface{}
+
+	// targetValue holds the current value of the target
+	// of a compound assignment, read by its TargetValue
+	targetValue interface{}
 }
 
 // NewExecutor ...
 func NewExecutor() *Executor {
-	result := &Executor{blocks: ast.NewBlockStack()}
+	result := &Executor{}
 	result.Reset()
 	return result
 }
 
-// CurrentBlock returns the current execution block
-func (e *Executor) CurrentBlock() *ast.Block {
-	return e.blocks.Peek()
-}
-
 // Reset sets the execution environment
 // back to it's initial state
 func (e *Executor) Reset() {
 	e.Errors = []error{}
+	e.frame = nil
+	e.globals = nil
+	e.calls = nil
+	e.returnValue = nil
 }
 
-// Execute executes the supplies AST
+// Execute executes the supplies AST.  Execution stops at
+// the first runtime error, which is added to Errors
 func (e *Executor) Execute(program *ast.Program) {
 	if program == nil {
 		e.addError(fmt.Errorf("Invalid program"))
@@ -43,99 +70,321 @@ func (e *Executor) Execute(program *ast.Program) {
 		return
 	}
 
-	e.executeBlock(program.BlockNode)
+	e.types = program.Types
+
+	e.globals = newFrame(program.BlockNode.Symbols, nil, e.types)
+	e.frame = e.globals
+
+	defer e.recoverRuntimeError()
+
+	if len(program.Functions) > 0 {
+		call := ast.NewCallExpression("main", 0, 0)
+		call.FunctionNode = program.GetFunction("main")
+		e.evaluateCall(call)
+		return
+	}
+
+	e.calls = []*StackEntry{{}}
+	e.executeStatements(program.BlockNode)
+}
+
+// raise stops the program with a runtime error at the
+// given position, unwinding back to Execute
+func (e *Executor) raise(line int, col int, format string, args ...interface{}) {
+	panic(e.newRuntimeError(line, col, fmt.Sprintf(format, args...)))
 }
 
-func (e *Executor) executeBlock(block *ast.Block) {
-	e.blocks.Push(block)
-	if e.CurrentBlock().StatementsNode == nil {
+// recoverRuntimeError catches the error raised by a program.
+// Any other panic comes from a problem in the interpreter
+// itself, and is reported at the last position reached
+func (e *Executor) recoverRuntimeError() {
+	r := recover()
+	if r == nil {
 		return
 	}
 
-	stmts := e.CurrentBlock().StatementsNode
-	for _, s := range stmts.StatementListNode {
-		switch s.(type) {
-		case *ast.NullStatement:
-			// do nothing
-		case *ast.PrintStatement:
-			e.executePrint(s.(*ast.PrintStatement))
-		case *ast.AssignStatement:
-			e.executeAssignment(s.(*ast.AssignStatement))
-		case *ast.VarStatement:
-			e.executeVar(s.(*ast.VarStatement))
-		case *ast.Block:
-			e.executeBlock(s.(*ast.Block))
+	err, isRuntimeError := r.(*RuntimeError)
+	if !isRuntimeError {
+		line, col := e.position()
+		err = e.newRuntimeError(line, col, fmt.Sprintf("Internal error: %v", r))
+	}
+
+	e.addError(err)
+}
+
+// newRuntimeError creates an error at the given
+// position, with a trace of the active calls
+func (e *Executor) newRuntimeError(line int, col int, message string) *RuntimeError {
+	e.setPosition(line, col)
+
+	result := &RuntimeError{Message: message, Filename: e.Filename, Line: line, Col: col}
+	for i := len(e.calls) - 1; i >= 0; i-- {
+		result.Stack = append(result.Stack, *e.calls[i])
+	}
+
+	return result
+}
+
+// setPosition records the position reached
+// by the innermost active call
+func (e *Executor) setPosition(line int, col int) {
+	if len(e.calls) > 0 {
+		e.calls[len(e.calls)-1].Line, e.calls[len(e.calls)-1].Col = line, col
+	}
+}
+
+func (e *Executor) position() (int, int) {
+	if len(e.calls) == 0 {
+		return 0, 0
+	}
+
+	return e.calls[len(e.calls)-1].Line, e.calls[len(e.calls)-1].Col
+}
+
+// controlFlow tells the enclosing statements how
+// execution should proceed after a statement completes
+type controlFlow int
+
+const (
+	flowNormal controlFlow = iota
+	flowBreak
+	flowContinue
+	flowReturn
+)
+
+// executeBlock runs the block in a new frame
+func (e *Executor) executeBlock(block *ast.Block) controlFlow {
+	saved := e.frame
+	e.frame = newFrame(block.Symbols, saved, e.types)
+	defer func() { e.frame = saved }()
+
+	return e.executeStatements(block)
+}
+
+// executeStatements runs the statements of a
+// block in the current frame
+func (e *Executor) executeStatements(block *ast.Block) controlFlow {
+	if block.StatementsNode == nil {
+		return flowNormal
+	}
+
+	for _, s := range block.StatementsNode.StatementListNode {
+		if flow := e.executeStatement(s); flow != flowNormal {
+			return flow
 		}
 	}
-	e.blocks.Pop()
+
+	return flowNormal
 }
 
-func (e *Executor) executeVar(s *ast.VarStatement) {
-	if symbol := s.SymbolNode; symbol != nil && s.ExpressionNode != nil {
-		if symbol, exists := e.CurrentBlock().Symbols.Get(s.SymbolNode.GetName()); exists {
-			switch symbol.GetDataType() {
-			case ast.TypeString:
-				symbol.SetValue(e.evaluateStringExpression(s.ExpressionNode.(*ast.StringExpression)))
-				return
-			case ast.TypeNumber:
-				symbol.SetValue(e.evaluateNumExpression(s.ExpressionNode.(*ast.NumExpression)))
-				return
-			default:
-				e.addError(fmt.Errorf("Unrecognized data type for variable %s", s.SymbolNode.GetName()))
-				return
-			}
+func (e *Executor) executeStatement(s ast.Statement) controlFlow {
+	e.trackPosition(s)
+
+	switch s.(type) {
+	case *ast.NullStatement:
+		// do nothing
+	case *ast.PrintStatement:
+		e.executePrint(s.(*ast.PrintStatement))
+	case *ast.AssignStatement:
+		e.executeAssignment(s.(*ast.AssignStatement))
+	case *ast.VarStatement:
+		e.executeVar(s.(*ast.VarStatement))
+	case *ast.Block:
+		return e.executeBlock(s.(*ast.Block))
+	case *ast.IfStatement:
+		return e.executeIf(s.(*ast.IfStatement))
+	case *ast.ForStatement:
+		return e.executeFor(s.(*ast.ForStatement))
+	case *ast.ForInStatement:
+		return e.executeForIn(s.(*ast.ForInStatement))
+	case *ast.BreakStatement:
+		return flowBreak
+	case *ast.ContinueStatement:
+		return flowContinue
+	case *ast.ReturnStatement:
+		if s.(*ast.ReturnStatement).ExpressionNode != nil {
+			e.returnValue = e.evaluateExpression(s.(*ast.ReturnStatement).ExpressionNode)
 		}
+		return flowReturn
+	case *ast.CallExpression:
+		e.evaluateCall(s.(*ast.CallExpression))
+	}
 
+	return flowNormal
+}
+
+// trackPosition records the position of each statement as
+// it starts, so an error from within the interpreter can
+// still be reported close to where it happened
+func (e *Executor) trackPosition(s ast.Statement) {
+	switch s.(type) {
+	case *ast.PrintStatement:
+		e.setPosition(s.(*ast.PrintStatement).Line, s.(*ast.PrintStatement).Col)
+	case *ast.AssignStatement:
+		e.setPosition(s.(*ast.AssignStatement).Line, s.(*ast.AssignStatement).Col)
+	case *ast.VarStatement:
+		e.setPosition(s.(*ast.VarStatement).Line, s.(*ast.VarStatement).Col)
+	case *ast.IfStatement:
+		e.setPosition(s.(*ast.IfStatement).Line, s.(*ast.IfStatement).Col)
+	case *ast.ForStatement:
+		e.setPosition(s.(*ast.ForStatement).Line, s.(*ast.ForStatement).Col)
+	case *ast.ForInStatement:
+		e.setPosition(s.(*ast.ForInStatement).Line, s.(*ast.ForInStatement).Col)
+	case *ast.ReturnStatement:
+		e.setPosition(s.(*ast.ReturnStatement).Line, s.(*ast.ReturnStatement).Col)
+	case *ast.CallExpression:
+		e.setPosition(s.(*ast.CallExpression).Line, s.(*ast.CallExpression).Col)
 	}
 }
 
-func (e *Executor) executeAssignment(s *ast.AssignStatement) {
-	if s.SymbolNode == nil || s.ExpressionNode == nil {
-		e.addError(fmt.Errorf("Attempted invalid assignment operation"))
-		return
+func (e *Executor) executeIf(s *ast.IfStatement) controlFlow {
+	if e.evaluateBool(s.ConditionNode) {
+		return e.executeBlock(s.BlockNode)
+	}
+
+	switch s.ElseNode.(type) {
+	case *ast.IfStatement:
+		return e.executeIf(s.ElseNode.(*ast.IfStatement))
+	case *ast.Block:
+		return e.executeBlock(s.ElseNode.(*ast.Block))
+	}
+
+	return flowNormal
+}
+
+func (e *Executor) executeFor(s *ast.ForStatement) controlFlow {
+	saved := e.frame
+	e.frame = newFrame(s.ScopeNode.Symbols, saved, e.types)
+	defer func() { e.frame = saved }()
+
+	if s.InitNode != nil {
+		e.executeStatement(s.InitNode)
 	}
 
-	if symbol, exists := e.CurrentBlock().Symbols.GetLocal(s.SymbolNode.GetName()); exists {
-		switch symbol.GetDataType() {
-		case ast.TypeString:
-			symbol.SetValue(e.evaluateStringExpression(s.ExpressionNode.(*ast.StringExpression)))
-			return
-		case ast.TypeNumber:
-			symbol.SetValue(e.evaluateNumExpression(s.ExpressionNode.(*ast.NumExpression)))
-			return
-		default:
-			e.addError(fmt.Errorf("Unrecognized data type for variable %s", s.SymbolNode.GetName()))
-			return
+	for s.ConditionNode == nil || e.evaluateBool(s.ConditionNode) {
+		switch e.executeBlock(s.BlockNode) {
+		case flowBreak:
+			return flowNormal
+		case flowReturn:
+			return flowReturn
+		}
+
+		if s.StepNode != nil {
+			e.executeStatement(s.StepNode)
 		}
 	}
 
-	e.addError(fmt.Errorf("Attempted assignment to undeclared variable %s", s.SymbolNode.GetName()))
+	return flowNormal
 }
 
-func (e *Executor) executePrint(s *ast.PrintStatement) {
-	switch s.ExpressionTypeID {
-	case ast.NumExpressionType:
-		exprResult := e.evaluateNumExpression(s.NumExpressionNode)
-		fmt.Print(exprResult.ToString())
-	case ast.StringExpressionType:
-		exprResult := e.evaluateStringExpression(s.StringExpressionNode)
-		fmt.Print(exprResult.GetValue())
+// executeForIn runs the block once for each entry of
+// the collection.  Each entry has a new frame for the
+// loop variables, so they start afresh each time
+func (e *Executor) executeForIn(s *ast.ForInStatement) controlFlow {
+	saved := e.frame
+	defer func() { e.frame = saved }()
+
+	iterator := e.evaluateExpression(s.CollectionNode).(ast.Iterable).Iterate()
+	for {
+		key, value, ok := iterator.Next()
+		if !ok {
+			break
+		}
+
+		e.frame = newFrame(s.ScopeNode.Symbols, saved, e.types)
+		if s.KeySymbol != nil {
+			e.frame.set(s.KeySymbol, key)
+		}
+		e.frame.set(s.ValueSymbol, value)
+
+		switch e.executeBlock(s.BlockNode) {
+		case flowBreak:
+			return flowNormal
+		case flowReturn:
+			return flowReturn
+		}
 	}
 
-	if s.WithEndline {
-		fmt.Println()
+	return flowNormal
+}
+
+func (e *Executor) executeVar(s *ast.VarStatement) {
+	if s.SymbolNode == nil {
+		return
+	}
+
+	// A declaration without a value resets the
+	// variable, since a block may run many times
+	if s.ExpressionNode == nil {
+		e.frame.set(s.SymbolNode, zeroValue(s.SymbolNode.GetDataType(), e.types))
+		return
 	}
+
+	e.frame.set(s.SymbolNode, e.evaluateExpression(s.ExpressionNode))
 }
 
-func (e *Executor) evaluateStringExpression(exp *ast.StringExpression) ast.StringValue {
-	result := exp.StringNode
+// executeAssignment works out where the target is
+// before evaluating the new value, so that an index or
+// struct in the target is evaluated only once, even by
+// a compound assignment that also reads the target
+func (e *Executor) executeAssignment(s *ast.AssignStatement) {
+	if s.ExpressionNode == nil {
+		e.raise(s.Line, s.Col, "Attempted invalid assignment operation")
+	}
 
-	if exp.StringExpressionNode != nil {
-		r2 := e.evaluateStringExpression(exp.StringExpressionNode.(*ast.StringExpression))
-		result.SetValue(result.GetValue() + r2.GetValue())
+	switch s.TargetNode.(type) {
+	case *ast.Identifier:
+		v := s.TargetNode.(*ast.Identifier)
+		if v.SymbolNode == nil {
+			e.raise(s.Line, s.Col, "Attempted assignment to undeclared variable %s", v.Name)
+		}
+		if s.Kind != ast.PlainAssignment {
+			e.targetValue = e.frame.get(v.SymbolNode)
+		}
+		if !e.frame.set(v.SymbolNode, e.evaluateExpression(s.ExpressionNode)) {
+			e.raise(s.Line, s.Col, "Attempted assignment to undeclared variable %s", v.Name)
+		}
+	case *ast.IndexExpression:
+		target := s.TargetNode.(*ast.IndexExpression)
+		collection := e.evaluateExpression(target.CollectionNode)
+		switch collection.(type) {
+		case *ast.Map:
+			m := collection.(*ast.Map)
+			key := e.evaluateExpression(target.IndexNode).(ast.StringValue).GetValue()
+			if s.Kind != ast.PlainAssignment {
+				value, exists := m.Get(key)
+				if !exists {
+					e.raise(target.Line, target.Col, "Key %s not found in map", strconv.Quote(key))
+				}
+				e.targetValue = value
+			}
+			m.Set(key, e.evaluateExpression(s.ExpressionNode))
+		case *ast.List:
+			list := collection.(*ast.List)
+			index := e.evaluateIndex(target.IndexNode, list.Len(), target.Line, target.Col)
+			if s.Kind != ast.PlainAssignment {
+				e.targetValue = list.Elements[index]
+			}
+			list.Elements[index] = e.evaluateExpression(s.ExpressionNode)
+		}
+	case *ast.FieldExpression:
+		target := s.TargetNode.(*ast.FieldExpression)
+		structValue := e.evaluateExpression(target.StructNode).(*ast.Struct)
+		if s.Kind != ast.PlainAssignment {
+			e.targetValue = structValue.Fields[target.Index]
+		}
+		structValue.Fields[target.Index] = e.evaluateExpression(s.ExpressionNode)
 	}
+}
 
-	return result
+func (e *Executor) executePrint(s *ast.PrintStatement) {
+	if s.ExpressionNode != nil {
+		fmt.Print(valueToString(e.evaluateExpression(s.ExpressionNode)))
+	}
+
+	if s.WithEndline {
+		fmt.Println()
+	}
 }
 
 func (e *Executor) addError(err error) {
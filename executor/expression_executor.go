@@ -0,0 +1,419 @@
+package executor
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// evaluateExpression evaluates any type of expression.
+// Values are never modified once created, so the result
+// may be shared with literals and variables
+func (e *Executor) evaluateExpression(exp ast.Expression) interface{} {
+	switch exp.(type) {
+	case *ast.BinaryExpression:
+		return e.evaluateBinaryExpression(exp.(*ast.BinaryExpression))
+	case *ast.UnaryExpression:
+		return e.evaluateUnaryExpression(exp.(*ast.UnaryExpression))
+	case *ast.CastExpression:
+		return e.evaluateCast(exp.(*ast.CastExpression))
+	case *ast.ListLiteral:
+		return e.evaluateListLiteral(exp.(*ast.ListLiteral))
+	case *ast.MapLiteral:
+		return e.evaluateMapLiteral(exp.(*ast.MapLiteral))
+	case *ast.StructLiteral:
+		return e.evaluateStructLiteral(exp.(*ast.StructLiteral))
+	case *ast.InterpolatedString:
+		var result strings.Builder
+		for _, part := range exp.(*ast.InterpolatedString).PartNodes {
+			result.WriteString(valueToString(e.evaluateExpression(part)))
+		}
+		return ast.NewString(result.String())
+	case *ast.IndexExpression:
+		return e.evaluateIndexExpression(exp.(*ast.IndexExpression))
+	case *ast.FieldExpression:
+		f := exp.(*ast.FieldExpression)
+		return e.evaluateExpression(f.StructNode).(*ast.Struct).Fields[f.Index]
+	case *ast.SliceExpression:
+		return e.evaluateSlice(exp.(*ast.SliceExpression))
+	case *ast.CallExpression:
+		return e.evaluateCall(exp.(*ast.CallExpression))
+	case *ast.Identifier:
+		return e.frame.get(exp.(*ast.Identifier).SymbolNode)
+	case *ast.TargetValue:
+		return e.targetValue
+	case *ast.Number, *ast.String, *ast.Bool:
+		return exp
+	}
+
+	return nil
+}
+
+// evaluateBool evaluates an expression of type bool
+func (e *Executor) evaluateBool(exp ast.Expression) bool {
+	return e.evaluateExpression(exp).(ast.BoolValue).GetValue()
+}
+
+func (e *Executor) evaluateBinaryExpression(b *ast.BinaryExpression) interface{} {
+	// "and" and "or" only evaluate their right
+	// side if it can change the result
+	switch b.Operator {
+	case ast.AndOperator:
+		return ast.NewBool(e.evaluateBool(b.LeftNode) && e.evaluateBool(b.RightNode))
+	case ast.OrOperator:
+		return ast.NewBool(e.evaluateBool(b.LeftNode) || e.evaluateBool(b.RightNode))
+	}
+
+	left := e.evaluateExpression(b.LeftNode)
+	right := e.evaluateExpression(b.RightNode)
+
+	if ast.IsComparisonOperator(b.Operator) {
+		return ast.NewBool(compareResult(b.Operator, compareValues(left, right)))
+	}
+
+	if b.DataType == ast.TypeString {
+		return ast.NewString(valueToString(left) + valueToString(right))
+	}
+
+	result, err := arithmetic(b.Operator, left.(ast.NumberValue), right.(ast.NumberValue))
+	if err != nil {
+		e.raise(b.Line, b.Col, "%s", err.Error())
+	}
+	return result
+}
+
+func (e *Executor) evaluateUnaryExpression(u *ast.UnaryExpression) interface{} {
+	switch u.Operator {
+	case ast.NotOperator:
+		return ast.NewBool(!e.evaluateBool(u.OperandNode))
+	case ast.MinusOperator:
+		r, err := e.evaluateExpression(u.OperandNode).(ast.NumberValue).Negate()
+		if err != nil {
+			e.raise(u.Line, u.Col, "%s", err.Error())
+		}
+		return &r
+	case ast.PlusOperator:
+		return e.evaluateExpression(u.OperandNode)
+	}
+
+	return nil
+}
+
+// evaluateCast converts a number to the cast's type
+func (e *Executor) evaluateCast(c *ast.CastExpression) interface{} {
+	value := e.evaluateExpression(c.ExpressionNode).(ast.NumberValue)
+
+	switch c.DataType {
+	case ast.TypeInt:
+		result, err := value.ToInt()
+		if err != nil {
+			e.raise(c.Line, c.Col, "%s", err.Error())
+		}
+		return &result
+	case ast.TypeFloat:
+		result := value.ToFloat()
+		return &result
+	}
+
+	return value
+}
+
+// evaluateListLiteral creates a new list each time it
+// runs, since a list can be changed once it is created
+func (e *Executor) evaluateListLiteral(l *ast.ListLiteral) interface{} {
+	elements := make([]interface{}, len(l.ElementNodes))
+	for i, n := range l.ElementNodes {
+		elements[i] = e.evaluateExpression(n)
+	}
+
+	return ast.NewList(l.DataType, elements)
+}
+
+// evaluateMapLiteral creates a new map each time it
+// runs.  If a key is repeated, the last value is kept
+func (e *Executor) evaluateMapLiteral(m *ast.MapLiteral) interface{} {
+	result := ast.NewMap(m.DataType)
+	for i := range m.KeyNodes {
+		key := e.evaluateExpression(m.KeyNodes[i]).(ast.StringValue).GetValue()
+		result.Set(key, e.evaluateExpression(m.ValueNodes[i]))
+	}
+
+	return result
+}
+
+// evaluateStructLiteral creates a new struct, with
+// any fields not given a value set to their zero value
+func (e *Executor) evaluateStructLiteral(l *ast.StructLiteral) interface{} {
+	result := zeroValue(l.DataType, e.types).(*ast.Struct)
+	for i, n := range l.ValueNodes {
+		result.Fields[l.FieldIndexes[i]] = e.evaluateExpression(n)
+	}
+
+	return result
+}
+
+func (e *Executor) evaluateIndexExpression(i *ast.IndexExpression) interface{} {
+	collection := e.evaluateExpression(i.CollectionNode)
+	switch collection.(type) {
+	case *ast.Map:
+		key := e.evaluateExpression(i.IndexNode).(ast.StringValue).GetValue()
+		value, exists := collection.(*ast.Map).Get(key)
+		if !exists {
+			e.raise(i.Line, i.Col, "Key %s not found in map", strconv.Quote(key))
+		}
+		return value
+	case *ast.List:
+		list := collection.(*ast.List)
+		return list.Elements[e.evaluateIndex(i.IndexNode, list.Len(), i.Line, i.Col)]
+	}
+
+	return nil
+}
+
+func (e *Executor) evaluateSlice(s *ast.SliceExpression) interface{} {
+	list := e.evaluateExpression(s.ListNode).(*ast.List)
+
+	low, high := 0, list.Len()
+	if s.LowNode != nil {
+		low = e.evaluateSliceBound(s.LowNode, list.Len(), s.Line, s.Col)
+	}
+	if s.HighNode != nil {
+		high = e.evaluateSliceBound(s.HighNode, list.Len(), s.Line, s.Col)
+	}
+
+	if low > high {
+		e.raise(s.Line, s.Col, "Slice bounds [%d:%d] out of order", low, high)
+	}
+	return list.Slice(low, high)
+}
+
+// evaluateIndex evaluates an index into a list of the
+// given length, which must be a whole number from 0 up
+// to one less than the length
+func (e *Executor) evaluateIndex(exp ast.Expression, length int, line int, col int) int {
+	index := e.evaluateInt(exp, line, col)
+	if index < 0 || index >= int64(length) {
+		e.raise(line, col, "Index %d out of range for list of length %d", index, length)
+	}
+	return int(index)
+}
+
+// evaluateSliceBound evaluates one end of a slice of a
+// list of the given length.  Unlike an index, it may be
+// equal to the length
+func (e *Executor) evaluateSliceBound(exp ast.Expression, length int, line int, col int) int {
+	bound := e.evaluateInt(exp, line, col)
+	if bound < 0 || bound > int64(length) {
+		e.raise(line, col, "Slice bound %d out of range for list of length %d", bound, length)
+	}
+	return int(bound)
+}
+
+// evaluateInt evaluates an index or slice bound,
+// which must be an int
+func (e *Executor) evaluateInt(exp ast.Expression, line int, col int) int64 {
+	n := e.evaluateExpression(exp).(ast.NumberValue)
+	if n.GetNumberType() != ast.IntType {
+		e.raise(line, col, "List index must be an int, found %s", n.ToString())
+	}
+	return n.GetIntValue()
+}
+
+// arithmetic applies a numeric operator, returning an
+// error for a division by zero or an int overflow
+func arithmetic(operator int, left ast.NumberValue, right ast.NumberValue) (*ast.Number, error) {
+	var result ast.Number
+	var err error
+
+	switch operator {
+	case ast.PlusOperator:
+		result, err = left.Add(right)
+	case ast.MinusOperator:
+		result, err = left.Sub(right)
+	case ast.MultOperator:
+		result, err = left.Mult(right)
+	case ast.DivOperator:
+		result, err = left.Div(right)
+	case ast.IntDivOperator:
+		result, err = left.IntDiv(right)
+	case ast.ModuloOperator:
+		result, err = left.Mod(right)
+	case ast.PowerOperator:
+		result, err = left.Pow(right)
+	}
+
+	return &result, err
+}
+
+// compareValues performs a three-way comparison of
+// two values of the same type.  Bools, lists, maps,
+// structs and ranges are only ever tested for equality,
+// so any difference is 1
+func compareValues(left interface{}, right interface{}) int {
+	return compareValuesIn(left, right, map[valuePair]bool{})
+}
+
+// valuePair is a pair of lists, maps or
+// structs that are being compared
+type valuePair struct {
+	left  interface{}
+	right interface{}
+}
+
+// compareValuesIn compares two values inside the lists,
+// maps and structs already being compared.  A struct can
+// hold a list of its own type, so a value can contain
+// itself, and a pair met again is taken to be equal, as
+// any difference will be found elsewhere
+func compareValuesIn(left interface{}, right interface{}, comparing map[valuePair]bool) int {
+	switch left.(type) {
+	case *ast.Struct, *ast.Map, *ast.List:
+		pair := valuePair{left: left, right: right}
+		if left == right || comparing[pair] {
+			return 0
+		}
+		comparing[pair] = true
+		defer delete(comparing, pair)
+	}
+
+	switch left.(type) {
+	case *ast.Range:
+		if *left.(*ast.Range) != *right.(*ast.Range) {
+			return 1
+		}
+	case *ast.Struct:
+		s1, s2 := left.(*ast.Struct), right.(*ast.Struct)
+		for i := range s1.Fields {
+			if compareValuesIn(s1.Fields[i], s2.Fields[i], comparing) != 0 {
+				return 1
+			}
+		}
+	case *ast.Map:
+		m1, m2 := left.(*ast.Map), right.(*ast.Map)
+		if m1.Len() != m2.Len() {
+			return 1
+		}
+		for k, v := range m1.Entries {
+			if other, exists := m2.Get(k); !exists || compareValuesIn(v, other, comparing) != 0 {
+				return 1
+			}
+		}
+	case *ast.List:
+		l1, l2 := left.(*ast.List), right.(*ast.List)
+		if l1.Len() != l2.Len() {
+			return 1
+		}
+		for i := range l1.Elements {
+			if compareValuesIn(l1.Elements[i], l2.Elements[i], comparing) != 0 {
+				return 1
+			}
+		}
+	case ast.NumberValue:
+		return left.(ast.NumberValue).Compare(right.(ast.NumberValue))
+	case ast.StringValue:
+		return strings.Compare(left.(ast.StringValue).GetValue(), right.(ast.StringValue).GetValue())
+	case ast.BoolValue:
+		if left.(ast.BoolValue).GetValue() != right.(ast.BoolValue).GetValue() {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// compareResult converts the result of a three-way
+// comparison into the result of the operator
+func compareResult(operator int, cmp int) bool {
+	switch operator {
+	case ast.EqualsOperator:
+		return cmp == 0
+	case ast.NotEqualsOperator:
+		return cmp != 0
+	case ast.LessThanOperator:
+		return cmp < 0
+	case ast.LessThanEqualsOperator:
+		return cmp <= 0
+	case ast.GreaterThanOperator:
+		return cmp > 0
+	case ast.GreaterThanEqualsOperator:
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// valueToString formats a value the way print does.
+// The elements of a list are separated by commas, with
+// strings in quotes, as in [1, 2] or ["a", "b"], and
+// the entries of a map are shown in the order of their
+// keys, as in {"a": 1, "b": 2}.  A struct is shown with
+// its type and fields, as in Point{x: 1, y: 2}
+func valueToString(value interface{}) string {
+	return valueToStringIn(value, map[interface{}]bool{})
+}
+
+// valueToStringIn formats a value inside the lists, maps
+// and structs already being formatted.  A value that
+// contains itself is shown as [...], {...} or, for a
+// struct, its type followed by {...} where it repeats
+func valueToStringIn(value interface{}, formatting map[interface{}]bool) string {
+	switch value.(type) {
+	case *ast.Struct, *ast.Map, *ast.List:
+		if formatting[value] {
+			switch value.(type) {
+			case *ast.Struct:
+				return value.(*ast.Struct).GetDeclaration().Name + "{...}"
+			case *ast.List:
+				return "[...]"
+			}
+			return "{...}"
+		}
+		formatting[value] = true
+		defer delete(formatting, value)
+	}
+
+	switch value.(type) {
+	case *ast.Struct:
+		s := value.(*ast.Struct)
+		fields := make([]string, len(s.Fields))
+		for i, f := range s.GetDeclaration().Fields {
+			fields[i] = f.Name + ": " + elementToString(s.Fields[i], formatting)
+		}
+		return s.GetDeclaration().Name + "{" + strings.Join(fields, ", ") + "}"
+	case *ast.List:
+		elements := make([]string, value.(*ast.List).Len())
+		for i, v := range value.(*ast.List).Elements {
+			elements[i] = elementToString(v, formatting)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *ast.Map:
+		m := value.(*ast.Map)
+		entries := make([]string, 0, m.Len())
+		for _, k := range m.Keys() {
+			v, _ := m.Get(k)
+			entries = append(entries, strconv.Quote(k)+": "+elementToString(v, formatting))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case ast.StringValue:
+		return value.(ast.StringValue).GetValue()
+	case ast.NumberValue:
+		return value.(ast.NumberValue).ToString()
+	case ast.BoolValue:
+		return value.(ast.BoolValue).ToString()
+	case *ast.Range:
+		return value.(*ast.Range).ToString()
+	}
+
+	return ""
+}
+
+// elementToString formats a value held in a list, a
+// map or a struct, which quotes it if it is a string
+func elementToString(value interface{}, formatting map[interface{}]bool) string {
+	if s, isString := value.(ast.StringValue); isString {
+		return strconv.Quote(s.GetValue())
+	}
+
+	return valueToStringIn(value, formatting)
+}
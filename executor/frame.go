@@ -0,0 +1,55 @@
+package executor
+
+import "github.com/hculpan/kablang/ast"
+
+// frame holds the values of the variables declared
+// by one activation of a block.  Each time a block
+// is entered or a function is called a new frame is
+// created, so the same AST can run recursively.
+type frame struct {
+	scope  *ast.SymbolTable
+	values []interface{}
+	parent *frame
+}
+
+// newFrame creates a frame for the given scope with
+// each variable set to the zero value of its type,
+// looked up in the program's types
+func newFrame(scope *ast.SymbolTable, parent *frame, types *ast.TypeTable) *frame {
+	result := &frame{scope: scope, parent: parent, values: make([]interface{}, len(scope.GetSlots()))}
+	for i, s := range scope.GetSlots() {
+		result.values[i] = zeroValue(s.GetDataType(), types)
+	}
+	return result
+}
+
+// find returns the frame that holds the
+// value for the given symbol
+func (f *frame) find(symbol ast.Symbol) *frame {
+	for curr := f; curr != nil; curr = curr.parent {
+		if curr.scope == symbol.GetScope() {
+			return curr
+		}
+	}
+
+	return nil
+}
+
+// get returns the current value of the symbol
+func (f *frame) get(symbol ast.Symbol) interface{} {
+	if owner := f.find(symbol); owner != nil {
+		return owner.values[symbol.GetSlot()]
+	}
+
+	return nil
+}
+
+// set changes the value of the symbol
+func (f *frame) set(symbol ast.Symbol, value interface{}) bool {
+	if owner := f.find(symbol); owner != nil {
+		owner.values[symbol.GetSlot()] = value
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package executor
+
+import (
+	"github.com/hculpan/kablang/ast"
+)
+
+// maxCallDepth limits how deeply calls can nest, so
+// runaway recursion is reported as a runtime error
+const maxCallDepth = 10000
+
+// evaluateCall calls a function, returning its
+// result or nil if it does not return a value
+func (e *Executor) evaluateCall(c *ast.CallExpression) interface{} {
+	f := c.FunctionNode
+
+	args := make([]interface{}, len(c.ArgumentNodes))
+	for i, a := range c.ArgumentNodes {
+		args[i] = e.evaluateExpression(a)
+	}
+
+	if f.FunctionCall != nil {
+		return e.callSystemFunction(c, args)
+	}
+
+	if len(e.calls) >= maxCallDepth {
+		e.raise(c.Line, c.Col, "Stack overflow in call to '%s'", f.Name)
+	}
+	e.setPosition(c.Line, c.Col)
+	e.calls = append(e.calls, &StackEntry{Function: f.Name, Line: f.Line, Col: f.Col})
+
+	// The function body runs in a new frame whose parent is
+	// the global frame, not the caller's, with the parameters
+	// occupying the first slots
+	callFrame := newFrame(f.BlockNode.Symbols, e.globals, e.types)
+	copy(callFrame.values, args)
+
+	saved := e.frame
+	e.frame = callFrame
+	e.returnValue = nil
+	flow := e.executeStatements(f.BlockNode)
+
+	if flow != flowReturn && f.ReturnDataType != ast.TypeNone {
+		e.raise(f.Line, f.Col, "Function '%s' ended without returning a value", f.Name)
+	}
+
+	e.frame = saved
+	e.calls = e.calls[:len(e.calls)-1]
+
+	result := e.returnValue
+	e.returnValue = nil
+	return result
+}
+
+// callSystemFunction calls a built-in function, converting
+// the arguments to and the result from Go values
+func (e *Executor) callSystemFunction(c *ast.CallExpression, args []interface{}) interface{} {
+	for i, a := range args {
+		switch a.(type) {
+		case ast.StringValue:
+			args[i] = a.(ast.StringValue).GetValue()
+		case ast.BoolValue:
+			args[i] = a.(ast.BoolValue).GetValue()
+		}
+	}
+
+	result := c.FunctionNode.FunctionCall(args)
+	if err, isError := result.(error); isError {
+		e.raise(c.Line, c.Col, "%s", err.Error())
+	}
+
+	switch c.DataType {
+	case ast.TypeString:
+		if s, ok := result.(string); ok {
+			return ast.NewString(s)
+		}
+	case ast.TypeBool:
+		if b, ok := result.(bool); ok {
+			return ast.NewBool(b)
+		}
+	case ast.TypeNumber, ast.TypeInt, ast.TypeFloat:
+		switch result.(type) {
+		case ast.NumberValue, ast.Number, int, int64, float64:
+			n := ast.NewIntNumber(0)
+			n.SetValue(result)
+			if result := numberResult(c.DataType, n); result != nil {
+				return result
+			}
+		}
+	case ast.TypeNone:
+		return nil
+	}
+
+	switch result.(type) {
+	case *ast.List:
+		if result.(*ast.List).GetDataType() == c.DataType {
+			return result
+		}
+	case *ast.Map:
+		if result.(*ast.Map).GetDataType() == c.DataType {
+			return result
+		}
+	case *ast.Struct:
+		if result.(*ast.Struct).GetDataType() == c.DataType {
+			return result
+		}
+	case *ast.Range:
+		if c.DataType == ast.TypeRange {
+			return result
+		}
+	}
+
+	e.raise(c.Line, c.Col, "Function '%s' returned %T, expected %s", c.FunctionNode.Name, result,
+		e.types.GetTypeName(c.DataType))
+	return nil
+}
+
+// numberResult checks that a number returned from a
+// built-in function matches its declared type, widening
+// an int to a float.  It returns nil if they don't match
+func numberResult(dataType int, n *ast.Number) interface{} {
+	switch {
+	case dataType == ast.TypeFloat:
+		result := n.ToFloat()
+		return &result
+	case dataType == ast.TypeInt && n.GetNumberType() != ast.IntType:
+		return nil
+	}
+
+	return n
+}
+
+// zeroValue returns the value a variable of
+// the given type has before it is assigned
+func zeroValue(dataType int, types *ast.TypeTable) interface{} {
+	switch dataType {
+	case ast.TypeString:
+		return ast.NewString("")
+	case ast.TypeNumber, ast.TypeInt:
+		return ast.NewIntNumber(0)
+	case ast.TypeFloat:
+		return ast.NewFloatNumber(0)
+	case ast.TypeBool:
+		return ast.NewBool(false)
+	}
+
+	switch {
+	case types.IsListType(dataType):
+		return ast.NewList(dataType, []interface{}{})
+	case types.IsMapType(dataType):
+		return ast.NewMap(dataType)
+	case types.IsStructType(dataType):
+		// A struct starts with each of its fields set to
+		// its zero value.  The checker makes sure a struct
+		// doesn't contain itself, so this always ends
+		s := types.GetStructDeclaration(dataType)
+		fields := make([]interface{}, len(s.Fields))
+		for i, f := range s.Fields {
+			fields[i] = zeroValue(f.DataType, types)
+		}
+		return ast.NewStruct(s, fields)
+	}
+
+	return nil
+}
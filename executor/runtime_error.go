@@ -0,0 +1,63 @@
+package executor
+
+import "fmt"
+
+// maxTraceEntries limits how much of a deep call
+// stack, such as one from runaway recursion, is shown
+const maxTraceEntries = 20
+
+// RuntimeError is an error that stops a running
+// program.  It records where in the source the error
+// happened and the calls that were active at the time
+type RuntimeError struct {
+	Message  string
+	Filename string
+	Line     int
+	Col      int
+
+	// Stack lists the active calls, innermost first
+	Stack []StackEntry
+}
+
+// StackEntry is one active call in a stack trace,
+// with the position that call had reached
+type StackEntry struct {
+	Function string
+	Line     int
+	Col      int
+}
+
+// Error returns the message and where it happened
+func (r *RuntimeError) Error() string {
+	return fmt.Sprintf("%s at %s", r.Message, position(r.Filename, r.Line, r.Col))
+}
+
+// StackTrace formats the stack as one line per
+// call, innermost first
+func (r *RuntimeError) StackTrace() []string {
+	result := []string{}
+	for i, s := range r.Stack {
+		if len(r.Stack) > maxTraceEntries && i == maxTraceEntries/2 {
+			result = append(result, fmt.Sprintf("... %d more calls ...", len(r.Stack)-maxTraceEntries))
+		}
+		if len(r.Stack) > maxTraceEntries && i >= maxTraceEntries/2 && i < len(r.Stack)-maxTraceEntries/2 {
+			continue
+		}
+
+		name := s.Function + "()"
+		if s.Function == "" {
+			name = "top level"
+		}
+		result = append(result, fmt.Sprintf("in %s at %s", name, position(r.Filename, s.Line, s.Col)))
+	}
+
+	return result
+}
+
+func position(filename string, line int, col int) string {
+	if filename == "" {
+		return fmt.Sprintf("line %d:%d", line, col)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", filename, line, col)
+}
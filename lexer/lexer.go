@@ -27,42 +27,85 @@ var initalized bool = false
 // Returns a list of all the tokens within the
 // given string.  This is meant to be a more generic
 // lexer, thus the keywords are passed in.
+//
+// The value of a String token keeps its quotes, with
+// any escape sequences between them decoded, while a
+// string holding expressions is split up as described
+// by lexString.  The rest of a line after a # is a
+// comment, and isn't split into tokens.
 func Lex(s string, currLine int) ([]Token, error) {
 	initTokenDefinitions()
 
+	result, err := lex(s, 0, len(s), currLine)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range result {
+		if result[i].TypeID != Identifier {
+			continue
+		}
+
+		for _, k := range keywords {
+			if result[i].Value == k.Match {
+				result[i].TypeID = k.TypeID
+				result[i].Name = k.Name
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// lex splits s[currLoc:end] into tokens.  The columns
+// of the tokens are their positions within all of s, so
+// that the expressions within a string can be lexed
+// where they are
+func lex(s string, currLoc int, end int, currLine int) ([]Token, error) {
 	result := []Token{}
 
-	currLoc := 0
-	for currLoc < len(s) {
+	for currLoc < end {
 		if s[currLoc] == ' ' || s[currLoc] == '\t' {
 			currLoc++
 			continue
 		}
 
+		// A string in double quotes can hold expressions,
+		// which can hold strings in turn, so it is found
+		// by lexString rather than a regular expression
+		if s[currLoc] == '"' {
+			tokens, next, err := lexString(s, currLoc, end, currLine)
+			if err != nil {
+				return result, err
+			}
+			result = append(result, tokens...)
+			currLoc = next
+			continue
+		}
+
 		found := false
 		for _, t := range tokenDefs {
 			if t.exp == nil {
 				continue
 			}
 
-			if i := t.exp.FindStringIndex(s[currLoc:]); i != nil {
+			if i := t.exp.FindStringIndex(s[currLoc:end]); i != nil {
 				result = append(result, *NewToken(t.TypeID, strings.Trim(s[currLoc:currLoc+i[1]], " \t"), t.Name, currLine, currLoc+1))
 				currLoc += i[1]
 				found = true
+
+				if t.TypeID == Hash {
+					currLoc = end
+					break
+				}
 			}
 		}
 
 		if !found {
-			return result, fmt.Errorf("No token match for '%s'", s[currLoc:])
-		}
-	}
-
-	for i := range result {
-		for _, k := range keywords {
-			if result[i].Value == k.Match {
-				result[i].TypeID = k.TypeID
-				result[i].Name = k.Name
+			if s[currLoc] == '`' {
+				return result, fmt.Errorf("Unterminated string at line %d:%d", currLine, currLoc+1)
 			}
+			return result, fmt.Errorf("No token match for '%s' at line %d:%d", s[currLoc:end], currLine, currLoc+1)
 		}
 	}
 
@@ -54,6 +54,34 @@ const (
 	Period
 	Newline
 	Hash
+	BoolType
+	True
+	False
+	And
+	Or
+	Semicolon
+	Break
+	Continue
+	Func
+	Return
+	Comma
+	MinusEquals
+	MultEquals
+	DivEquals
+	DoubleMinus
+	DoubleDiv
+	IntType
+	FloatType
+	LeftBracket
+	RightBracket
+	Colon
+	MapType
+	Type
+	Struct
+	In
+	StringStart
+	StringMiddle
+	StringEnd
 	EndTokenList
 )
 
@@ -80,24 +108,45 @@ var keywords []TokenDef = []TokenDef{
 	newTokenDef(For, `for`, "For"),
 	newTokenDef(If, `if`, "If"),
 	newTokenDef(Else, `else`, "Else"),
+	newTokenDef(StringType, "string", "String"),
+	newTokenDef(NumberType, "number", "Number"),
+	newTokenDef(BoolType, "bool", "Bool"),
+	newTokenDef(IntType, "int", "Int"),
+	newTokenDef(FloatType, "float", "Float"),
+	newTokenDef(MapType, "map", "Map"),
+	newTokenDef(True, "true", "True"),
+	newTokenDef(False, "false", "False"),
+	newTokenDef(And, "and", "And"),
+	newTokenDef(Or, "or", "Or"),
+	newTokenDef(Not, "not", "Not"),
+	newTokenDef(Break, "break", "Break"),
+	newTokenDef(Continue, "continue", "Continue"),
+	newTokenDef(Func, "func", "Func"),
+	newTokenDef(Return, "return", "Return"),
+	newTokenDef(Type, "type", "Type"),
+	newTokenDef(Struct, "struct", "Struct"),
+	newTokenDef(In, "in", "In"),
 }
 
 var tokenDefs []TokenDef = []TokenDef{
 	newTokenDef(Identifier, `^[a-zA-Z][a-zA-Z_0-9]*`, "Identifier"),
-	newTokenDef(StringType, "^string", "String"),
-	newTokenDef(NumberType, "^number", "Number"),
 	newTokenDef(Integer, `^[0-9]+`, "Integer"),
 	newTokenDef(Float, `^[0-9]+\.[0-9]*`, "Float"),
 	newTokenDef(Percent, `^%`, "Percent"),
 	newTokenDef(Dash, `^-`, "Dash"),
+	newTokenDef(MinusEquals, `^-=`, "Minus Equals"),
+	newTokenDef(DoubleMinus, `^--`, "Double Minus"),
 	newTokenDef(Exponent, `^\^`, "Exponent"),
 	newTokenDef(Plus, `^\+`, "Plus"),
 	newTokenDef(PlusEquals, `^\+=`, "Plus Equals"),
 	newTokenDef(DoublePlus, `^\+\+`, "Double Plus"),
 	newTokenDef(Mult, `^\*`, "Mult"),
+	newTokenDef(MultEquals, `^\*=`, "Mult Equals"),
 	newTokenDef(Div, `^/`, "Div"),
+	newTokenDef(DivEquals, `^/=`, "Div Equals"),
+	newTokenDef(DoubleDiv, `^//`, "Double Div"),
 	newTokenDef(Equals, `^=`, "Equals"),
-	newTokenDef(String, `^\"[^\"]*\"`, "String"),
+	newTokenDef(String, "^`[^`]*`", "String"),
 	newTokenDef(LeftCurlyBrace, `^\{`, "Left Curly Brace"),
 	newTokenDef(RightCurlyBrace, `^\}`, "Right Curly Brace"),
 	newTokenDef(LeftParen, `^\(`, "Left Paren"),
@@ -110,8 +159,16 @@ var tokenDefs []TokenDef = []TokenDef{
 	newTokenDef(Not, `^!`, "Not"),
 	newTokenDef(NotEquals, `^!=`, "Not Equals"),
 	newTokenDef(Period, `^\.`, "Period"),
+	newTokenDef(LeftBracket, `^\[`, "Left Bracket"),
+	newTokenDef(RightBracket, `^\]`, "Right Bracket"),
+	newTokenDef(Colon, `^:`, "Colon"),
 	newTokenDef(Newline, `^[\n]+`, "Newline"),
 	newTokenDef(Hash, `^#`, "Hash"),
+	newTokenDef(Semicolon, `^;`, "Semicolon"),
+	newTokenDef(Comma, `^,`, "Comma"),
+	newTokenDef(StringStart, ``, "String Start"),
+	newTokenDef(StringMiddle, ``, "String Middle"),
+	newTokenDef(StringEnd, ``, "String End"),
 	newTokenDef(EndTokenList, ``, "End of tokens"),
 }
 
@@ -131,11 +188,19 @@ func newTokenDef(typeID TokenType, match string, name string) TokenDef {
 }
 
 // GetTokenDef returns the token definition
-// for the specified type id
+// for the specified type id, checking the
+// token definitions first and then the keywords
 func GetTokenDef(typeID TokenType) *TokenDef {
 	var result *TokenDef = nil
 
 	for _, v := range tokenDefs {
+		if v.TypeID == typeID {
+			result = &v
+			return result
+		}
+	}
+
+	for _, v := range keywords {
 		if v.TypeID == typeID {
 			result = &v
 			break
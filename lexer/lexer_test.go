@@ -355,6 +355,318 @@ func TestLexer15_KeywordNoSpace(t *testing.T) {
 	}
 }
 
+func TestLexer16_BoolKeywords(t *testing.T) {
+	r, err := Lex(`var ok bool = true and not false or !done`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 11
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: Var, Value: "var"})
+		testToken(t, r[1], Token{TypeID: Identifier, Value: "ok"})
+		testToken(t, r[2], Token{TypeID: BoolType, Value: "bool"})
+		testToken(t, r[3], Token{TypeID: Equals, Value: "="})
+		testToken(t, r[4], Token{TypeID: True, Value: "true"})
+		testToken(t, r[5], Token{TypeID: And, Value: "and"})
+		testToken(t, r[6], Token{TypeID: Not, Value: "not"})
+		testToken(t, r[7], Token{TypeID: False, Value: "false"})
+		testToken(t, r[8], Token{TypeID: Or, Value: "or"})
+		testToken(t, r[9], Token{TypeID: Not, Value: "!"})
+		testToken(t, r[10], Token{TypeID: Identifier, Value: "done"})
+	}
+}
+
+// Type names are keywords, so an identifier that
+// starts with one must still be a single identifier
+func TestLexer17_TypeNameInIdentifier(t *testing.T) {
+	r, err := Lex(`var stringValue string`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 3
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: Var, Value: "var"})
+		testToken(t, r[1], Token{TypeID: Identifier, Value: "stringValue"})
+		testToken(t, r[2], Token{TypeID: StringType, Value: "string"})
+	}
+}
+
+func TestLexer18_ForLoop(t *testing.T) {
+	r, err := Lex(`for var i number = 0; i < 3; i = i + 1 { break continue }`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 20
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: For, Value: "for"})
+		testToken(t, r[1], Token{TypeID: Var, Value: "var"})
+		testToken(t, r[6], Token{TypeID: Semicolon, Value: ";"})
+		testToken(t, r[10], Token{TypeID: Semicolon, Value: ";"})
+		testToken(t, r[16], Token{TypeID: LeftCurlyBrace, Value: "{"})
+		testToken(t, r[17], Token{TypeID: Break, Value: "break"})
+		testToken(t, r[18], Token{TypeID: Continue, Value: "continue"})
+		testToken(t, r[19], Token{TypeID: RightCurlyBrace, Value: "}"})
+	}
+}
+
+func TestLexer19_FunctionDeclaration(t *testing.T) {
+	r, err := Lex(`func add(a number, b number) number { return a + b }`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 16
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: Func, Value: "func"})
+		testToken(t, r[1], Token{TypeID: Identifier, Value: "add"})
+		testToken(t, r[2], Token{TypeID: LeftParen, Value: "("})
+		testToken(t, r[5], Token{TypeID: Comma, Value: ","})
+		testToken(t, r[8], Token{TypeID: RightParen, Value: ")"})
+		testToken(t, r[9], Token{TypeID: NumberType, Value: "number"})
+		testToken(t, r[11], Token{TypeID: Return, Value: "return"})
+	}
+}
+
+func TestLexer20_CompoundAssignment(t *testing.T) {
+	r, err := Lex(`x += 1 y -= 2 z *= 3 w /= 4 i++ j--`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 16
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[1], Token{TypeID: PlusEquals, Value: "+="})
+		testToken(t, r[4], Token{TypeID: MinusEquals, Value: "-="})
+		testToken(t, r[7], Token{TypeID: MultEquals, Value: "*="})
+		testToken(t, r[10], Token{TypeID: DivEquals, Value: "/="})
+		testToken(t, r[13], Token{TypeID: DoublePlus, Value: "++"})
+		testToken(t, r[15], Token{TypeID: DoubleMinus, Value: "--"})
+	}
+}
+
+func TestLexer21_IntegerDivision(t *testing.T) {
+	r, err := Lex(`a // b / c /= d`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 7
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[1], Token{TypeID: DoubleDiv, Value: "//"})
+		testToken(t, r[3], Token{TypeID: Div, Value: "/"})
+		testToken(t, r[5], Token{TypeID: DivEquals, Value: "/="})
+	}
+}
+
+func TestLexer22_NumericTypes(t *testing.T) {
+	r, err := Lex(`var i int = int(2.5) var f float integer`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 12
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[2], Token{TypeID: IntType, Value: "int"})
+		testToken(t, r[4], Token{TypeID: IntType, Value: "int"})
+		testToken(t, r[6], Token{TypeID: Float, Value: "2.5"})
+		testToken(t, r[10], Token{TypeID: FloatType, Value: "float"})
+		testToken(t, r[11], Token{TypeID: Identifier, Value: "integer"})
+	}
+}
+
+func TestLexer23_Lists(t *testing.T) {
+	r, err := Lex(`var a []int = [1, 2] a[0:1]`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 17
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[2], Token{TypeID: LeftBracket, Value: "["})
+		testToken(t, r[3], Token{TypeID: RightBracket, Value: "]"})
+		testToken(t, r[6], Token{TypeID: LeftBracket, Value: "["})
+		testToken(t, r[10], Token{TypeID: RightBracket, Value: "]"})
+		testToken(t, r[14], Token{TypeID: Colon, Value: ":"})
+	}
+}
+
+func TestLexer24_Maps(t *testing.T) {
+	r, err := Lex(`var m map[string]int = {"a": 1} m["a"]`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 17
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[2], Token{TypeID: MapType, Value: "map"})
+		testToken(t, r[4], Token{TypeID: StringType, Value: "string"})
+		testToken(t, r[8], Token{TypeID: LeftCurlyBrace, Value: "{"})
+		testToken(t, r[10], Token{TypeID: Colon, Value: ":"})
+		testToken(t, r[13], Token{TypeID: Identifier, Value: "m"})
+	}
+}
+
+func TestLexer25_Structs(t *testing.T) {
+	r, err := Lex(`type Point struct { x int; y int } p.x`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 13
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: Type, Value: "type"})
+		testToken(t, r[1], Token{TypeID: Identifier, Value: "Point"})
+		testToken(t, r[2], Token{TypeID: Struct, Value: "struct"})
+		testToken(t, r[6], Token{TypeID: Semicolon, Value: ";"})
+		testToken(t, r[11], Token{TypeID: Period, Value: "."})
+		testToken(t, r[12], Token{TypeID: Identifier, Value: "x"})
+	}
+}
+
+func TestLexer26_ForIn(t *testing.T) {
+	r, err := Lex(`for i, v in range(0, 10) { index }`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 14
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: For, Value: "for"})
+		testToken(t, r[2], Token{TypeID: Comma, Value: ","})
+		testToken(t, r[4], Token{TypeID: In, Value: "in"})
+		testToken(t, r[5], Token{TypeID: Identifier, Value: "range"})
+		testToken(t, r[12], Token{TypeID: Identifier, Value: "index"})
+	}
+}
+
+func TestLexer27_StringEscapes(t *testing.T) {
+	r, err := Lex(`"a\"b\\c\n\t\u{e9}" `+"`raw\\n`"+` # "comment`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 3
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: String, Value: "\"a\"b\\c\n\t\u00e9\""})
+		testToken(t, r[1], Token{TypeID: String, Value: "`raw\\n`"})
+		testToken(t, r[2], Token{TypeID: Hash, Value: "#"})
+	}
+}
+
+func TestLexer28_StringErrors(t *testing.T) {
+	tests := map[string]string{
+		`x = "abc`:         "Unterminated string at line 3:5",
+		`x = "abc\"`:       "Unterminated string at line 3:5",
+		"x = `abc":         "Unterminated string at line 3:5",
+		`x = "a\qb"`:       "Invalid escape sequence '\\q' at line 3:7",
+		`x = "\u{110000}"`: "Invalid character code '\\u{110000}' at line 3:6",
+		`x = "\u41"`:       "Expected \\u{hex digits} at line 3:6",
+	}
+
+	for input, expected := range tests {
+		if _, err := Lex(input, 3); err == nil || err.Error() != expected {
+			t.Log(fmt.Sprintf("Expected error '%s' for %s, found %v", expected, input, err))
+			t.Fail()
+		}
+	}
+}
+
+func TestLexer29_Interpolation(t *testing.T) {
+	r, err := Lex(`"a ${x + 1} b ${upper("${y}")}\${z}"`, 1)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+
+	expectedCount := 12
+	if len(r) != expectedCount {
+		t.Log(fmt.Sprintf("Expected %d tokens, found %d", expectedCount, len(r)))
+		t.Fail()
+	} else {
+		testToken(t, r[0], Token{TypeID: StringStart, Value: "a "})
+		testToken(t, r[1], Token{TypeID: Identifier, Value: "x"})
+		testToken(t, r[4], Token{TypeID: StringMiddle, Value: " b "})
+		testToken(t, r[5], Token{TypeID: Identifier, Value: "upper"})
+		testToken(t, r[7], Token{TypeID: StringStart, Value: ""})
+		testToken(t, r[8], Token{TypeID: Identifier, Value: "y"})
+		testToken(t, r[9], Token{TypeID: StringEnd, Value: ""})
+		testToken(t, r[11], Token{TypeID: StringEnd, Value: "${z}"})
+		if r[1].Col != 6 || r[4].Col != 11 {
+			t.Log(fmt.Sprintf("Expected columns 6 and 11, found %d and %d", r[1].Col, r[4].Col))
+			t.Fail()
+		}
+	}
+
+	if _, err := Lex(`"a ${x`, 2); err == nil || err.Error() != "Unterminated ${ in string at line 2:4" {
+		t.Log(fmt.Sprintf("Expected unterminated ${ error, found %v", err))
+		t.Fail()
+	}
+}
+
 func testToken(t *testing.T, token Token, expected Token) {
 	if !token.Equals(expected) {
 		t.Log(fmt.Sprintf("Expected %s, found %s [%s]", expected.TypeID.String(), token.TypeID.String(), token.Value))
@@ -0,0 +1,125 @@
+package lexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// lexString lexes the string in double quotes that
+// starts at s[start], returning its tokens and the
+// position after it.  A string with no ${...} in it is
+// a single String token.  Otherwise it is split into a
+// StringStart token for the text before the first
+// expression, the tokens of each expression, a
+// StringMiddle token for the text between two, and a
+// StringEnd token for the text after the last
+func lexString(s string, start int, end int, line int) ([]Token, int, error) {
+	result := []Token{}
+	partType, partCol := StringStart, start+1
+
+	var text strings.Builder
+	for i := start + 1; i < end; i++ {
+		switch {
+		case s[i] == '"':
+			if len(result) == 0 {
+				return []Token{*NewToken(String, `"`+text.String()+`"`, "String", line, start+1)}, i + 1, nil
+			}
+			return append(result, *NewToken(StringEnd, text.String(), "String End", line, partCol)), i + 1, nil
+		case s[i] == '\\':
+			length, err := decodeEscape(s[i:end], &text)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%s at line %d:%d", err.Error(), line, i+1)
+			}
+			i += length - 1
+		case s[i] == '$' && i+1 < end && s[i+1] == '{':
+			close, err := findInterpolationEnd(s, i+2, end, line)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			result = append(result, *NewToken(partType, text.String(), GetTokenDef(partType).Name, line, partCol))
+			tokens, err := lex(s, i+2, close, line)
+			if err != nil {
+				return nil, 0, err
+			}
+			result = append(result, tokens...)
+
+			text.Reset()
+			partType, partCol = StringMiddle, close+1
+			i = close
+		default:
+			text.WriteByte(s[i])
+		}
+	}
+
+	return nil, 0, fmt.Errorf("Unterminated string at line %d:%d", line, start+1)
+}
+
+// findInterpolationEnd finds the } that closes the
+// expression starting at s[start], skipping over any
+// strings or braces within the expression
+func findInterpolationEnd(s string, start int, end int, line int) (int, error) {
+	depth := 0
+	for i := start; i < end; i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i, nil
+			}
+			depth--
+		case '"':
+			_, next, err := lexString(s, i, end, line)
+			if err != nil {
+				return 0, err
+			}
+			i = next - 1
+		case '`':
+			next := strings.IndexByte(s[i+1:end], '`')
+			if next < 0 {
+				return 0, fmt.Errorf("Unterminated string at line %d:%d", line, i+1)
+			}
+			i += next + 1
+		}
+	}
+
+	return 0, fmt.Errorf("Unterminated ${ in string at line %d:%d", line, start-1)
+}
+
+// decodeEscape writes the character stood for by the
+// escape sequence at the start of s, returning the
+// length of the escape sequence
+func decodeEscape(s string, text *strings.Builder) (int, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("Invalid escape sequence '\\'")
+	}
+
+	switch s[1] {
+	case 'n':
+		text.WriteByte('\n')
+	case 't':
+		text.WriteByte('\t')
+	case '"', '\\', '$':
+		text.WriteByte(s[1])
+	case 'u':
+		// There are at most 6 hex digits
+		close := strings.IndexByte(s, '}')
+		if len(s) < 3 || s[2] != '{' || close < 0 || close > 9 {
+			return 0, fmt.Errorf("Expected \\u{hex digits}")
+		}
+
+		code, err := strconv.ParseUint(s[3:close], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return 0, fmt.Errorf("Invalid character code '%s'", s[:close+1])
+		}
+		text.WriteRune(rune(code))
+		return close + 1, nil
+	default:
+		return 0, fmt.Errorf("Invalid escape sequence '\\%c'", s[1])
+	}
+
+	return 2, nil
+}
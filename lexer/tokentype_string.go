@@ -43,12 +43,40 @@ func _() {
 	_ = x[Period-32]
 	_ = x[Newline-33]
 	_ = x[Hash-34]
-	_ = x[EndTokenList-35]
+	_ = x[BoolType-35]
+	_ = x[True-36]
+	_ = x[False-37]
+	_ = x[And-38]
+	_ = x[Or-39]
+	_ = x[Semicolon-40]
+	_ = x[Break-41]
+	_ = x[Continue-42]
+	_ = x[Func-43]
+	_ = x[Return-44]
+	_ = x[Comma-45]
+	_ = x[MinusEquals-46]
+	_ = x[MultEquals-47]
+	_ = x[DivEquals-48]
+	_ = x[DoubleMinus-49]
+	_ = x[DoubleDiv-50]
+	_ = x[IntType-51]
+	_ = x[FloatType-52]
+	_ = x[LeftBracket-53]
+	_ = x[RightBracket-54]
+	_ = x[Colon-55]
+	_ = x[MapType-56]
+	_ = x[Type-57]
+	_ = x[Struct-58]
+	_ = x[In-59]
+	_ = x[StringStart-60]
+	_ = x[StringMiddle-61]
+	_ = x[StringEnd-62]
+	_ = x[EndTokenList-63]
 }
 
-const _TokenType_name = "IdentifierPrintlnPrintVarStringTypeNumberTypeForIfElseIntegerFloatPercentDashPlusPlusEqualsDoublePlusMultDivExponentEqualsStringLeftCurlyBraceRightCurlyBraceLeftParenRightParenLessThanEqualsLessThanGreaterThanEqualsGreaterThanDoubleEqualsNotNotEqualsPeriodNewlineHashEndTokenList"
+const _TokenType_name = "IdentifierPrintlnPrintVarStringTypeNumberTypeForIfElseIntegerFloatPercentDashPlusPlusEqualsDoublePlusMultDivExponentEqualsStringLeftCurlyBraceRightCurlyBraceLeftParenRightParenLessThanEqualsLessThanGreaterThanEqualsGreaterThanDoubleEqualsNotNotEqualsPeriodNewlineHashBoolTypeTrueFalseAndOrSemicolonBreakContinueFuncReturnCommaMinusEqualsMultEqualsDivEqualsDoubleMinusDoubleDivIntTypeFloatTypeLeftBracketRightBracketColonMapTypeTypeStructInStringStartStringMiddleStringEndEndTokenList"
 
-var _TokenType_index = [...]uint16{0, 10, 17, 22, 25, 35, 45, 48, 50, 54, 61, 66, 73, 77, 81, 91, 101, 105, 108, 116, 122, 128, 142, 157, 166, 176, 190, 198, 215, 226, 238, 241, 250, 256, 263, 267, 279}
+var _TokenType_index = [...]uint16{0, 10, 17, 22, 25, 35, 45, 48, 50, 54, 61, 66, 73, 77, 81, 91, 101, 105, 108, 116, 122, 128, 142, 157, 166, 176, 190, 198, 215, 226, 238, 241, 250, 256, 263, 267, 275, 279, 284, 287, 289, 298, 303, 311, 315, 321, 326, 337, 347, 356, 367, 376, 383, 392, 403, 415, 420, 427, 431, 437, 439, 450, 462, 471, 483}
 
 func (i TokenType) String() string {
 	if i < 0 || i >= TokenType(len(_TokenType_index)-1) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/hculpan/kablang/ast"
+	"github.com/hculpan/kablang/checker"
 	"github.com/hculpan/kablang/executor"
 	"github.com/hculpan/kablang/parser"
 )
@@ -17,20 +18,21 @@ import (
 
 var outputAST bool = false
 var outputSymbols bool = false
+var warnShadowing bool = false
 
 var inputFilename string
 var inputFilenameBase string
 
 func main() {
 	if !processCommandLine() {
-		return
+		os.Exit(2)
 	}
 
 	fmt.Println("Kab Interpreter v0.1")
 	lines, err := readInputFile(inputFilename)
 	if err != nil {
 		fmt.Println(err)
-		return
+		os.Exit(1)
 	}
 
 	inputFilenameBase = inputFilename[:len(inputFilename)-(len(filepath.Ext(inputFilename)))]
@@ -38,9 +40,23 @@ func main() {
 	parser := parser.NewParser()
 	program, errs := parser.Parse(lines)
 
+	// The lexer's errors leave no program to check
 	if program == nil {
-		fmt.Println("We have an invalid program node")
-		return
+		printErrors(errs)
+		os.Exit(1)
+	}
+
+	if len(errs) == 0 {
+		checker := checker.NewChecker()
+		checker.WarnShadowing = warnShadowing
+		errs = checker.Check(program)
+
+		if warnings := checker.Warnings(); len(warnings) > 0 {
+			fmt.Println("Warnings reported:")
+			for _, w := range warnings {
+				fmt.Println("    ", w)
+			}
+		}
 	}
 
 	if outputAST {
@@ -52,20 +68,44 @@ func main() {
 	}
 
 	if len(errs) > 0 {
-		fmt.Println("Errors reported:")
-		for _, e := range errs {
-			fmt.Println("    ", e)
-		}
-		return
+		printErrors(errs)
+		os.Exit(1)
 	}
 
 	ex := executor.NewExecutor()
+	ex.Filename = inputFilename
 	ex.Execute(program)
+
+	if len(ex.Errors) > 0 {
+		printRuntimeErrors(ex.Errors)
+		os.Exit(1)
+	}
+}
+
+func printErrors(errs []error) {
+	fmt.Println("Errors reported:")
+	for _, e := range errs {
+		fmt.Println("    ", e)
+	}
+}
+
+// printRuntimeErrors prints the errors that stopped
+// the program, with a trace of the active calls
+func printRuntimeErrors(errs []error) {
+	for _, e := range errs {
+		fmt.Println("Runtime error:", e)
+		if r, ok := e.(*executor.RuntimeError); ok {
+			for _, s := range r.StackTrace() {
+				fmt.Println("    ", s)
+			}
+		}
+	}
 }
 
 func processCommandLine() bool {
 	flag.BoolVar(&outputAST, "a", false, "Output AST")
 	flag.BoolVar(&outputSymbols, "s", false, "Output symbols")
+	flag.BoolVar(&warnShadowing, "w", false, "Warn when a variable shadows another")
 	flag.Parse()
 	if inputFilename = flag.Arg(0); len(flag.Args()) != 1 || inputFilename == "" {
 		fmt.Println("Error: Incorrect arguments")
@@ -83,6 +123,7 @@ func printHelp() {
 	fmt.Println("  Options:")
 	fmt.Println("        -a    Output AST")
 	fmt.Println("        -s    Output symbols")
+	fmt.Println("        -w    Warn when a variable shadows another")
 	fmt.Println()
 }
 
@@ -98,7 +139,7 @@ func readInputFile(filename string) ([]string, error) {
 	scanner := bufio.NewScanner(f)
 
 	for scanner.Scan() {
-		result = append(result, strings.Trim(scanner.Text(), " "))
+		result = append(result, strings.TrimRight(scanner.Text(), " \r"))
 	}
 
 	return result, nil
@@ -128,8 +169,8 @@ func outputSymbolsToFile(filenameBase string, program *ast.Program) {
 
 	writer := bufio.NewWriter(file)
 	_, err = writer.WriteString("Symbols:\n")
-	for _, v := range program.BlockNode.Symbols.GetSymbols() {
-		_, err = writer.WriteString(v.AsString("  ") + "\n")
+	for _, v := range program.BlockNode.Symbols.GetSlots() {
+		_, err = writer.WriteString(v.AsString("  ", program.Types) + "\n")
 	}
 	if err != nil {
 		panic(err)
@@ -0,0 +1,377 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hculpan/kablang/ast"
+	"github.com/hculpan/kablang/lexer"
+)
+
+// Precedence of the operators, from the
+// loosest binding to the tightest
+const (
+	orPrecedence = iota + 1
+	andPrecedence
+	notPrecedence
+	comparisonPrecedence
+	additivePrecedence
+	multiplicativePrecedence
+	powerPrecedence
+)
+
+// operatorDef describes an operator that
+// appears between its two operands
+type operatorDef struct {
+	operator         int
+	precedence       int
+	rightAssociative bool
+}
+
+// binaryOperators lists the tokens that are binary
+// operators.  To add an operator, add its token here
+// and its result type to ast.BinaryResultType.
+var binaryOperators map[lexer.TokenType]operatorDef = map[lexer.TokenType]operatorDef{
+	lexer.Or:                {ast.OrOperator, orPrecedence, false},
+	lexer.And:               {ast.AndOperator, andPrecedence, false},
+	lexer.DoubleEquals:      {ast.EqualsOperator, comparisonPrecedence, false},
+	lexer.NotEquals:         {ast.NotEqualsOperator, comparisonPrecedence, false},
+	lexer.LessThan:          {ast.LessThanOperator, comparisonPrecedence, false},
+	lexer.LessThanEquals:    {ast.LessThanEqualsOperator, comparisonPrecedence, false},
+	lexer.GreaterThan:       {ast.GreaterThanOperator, comparisonPrecedence, false},
+	lexer.GreaterThanEquals: {ast.GreaterThanEqualsOperator, comparisonPrecedence, false},
+	lexer.Plus:              {ast.PlusOperator, additivePrecedence, false},
+	lexer.Dash:              {ast.MinusOperator, additivePrecedence, false},
+	lexer.Mult:              {ast.MultOperator, multiplicativePrecedence, false},
+	lexer.Div:               {ast.DivOperator, multiplicativePrecedence, false},
+	lexer.DoubleDiv:         {ast.IntDivOperator, multiplicativePrecedence, false},
+	lexer.Percent:           {ast.ModuloOperator, multiplicativePrecedence, false},
+	lexer.Exponent:          {ast.PowerOperator, powerPrecedence, true},
+}
+
+// parseExpression parses an expression of any type.
+// Its type is worked out later, by the checker
+func (p *Parser) parseExpression() ast.Expression {
+	return p.parseBinaryExpression(orPrecedence)
+}
+
+// parseBinaryExpression parses a series of operands joined
+// by operators of at least the given precedence.  The right
+// operand of each operator only takes in operators that bind
+// tighter, so "10 - 2 - 3" groups as "(10 - 2) - 3"; for a
+// right associative operator it also takes in operators of
+// the same precedence, so "2 ^ 3 ^ 2" is "2 ^ (3 ^ 2)".
+func (p *Parser) parseBinaryExpression(precedence int) ast.Expression {
+	left := p.parseUnaryExpression()
+
+	for left != nil {
+		t := p.lexerHandler.Peek()
+		op, isOperator := binaryOperators[t.TypeID]
+		if !isOperator || op.precedence < precedence {
+			break
+		}
+		p.lexerHandler.Pop()
+
+		next := op.precedence + 1
+		if op.rightAssociative {
+			next = op.precedence
+		}
+
+		right := p.parseBinaryExpression(next)
+		if right == nil {
+			return nil
+		}
+		left = ast.NewBinaryExpression(op.operator, left, right, t.Line, t.Col)
+	}
+
+	return left
+}
+
+// parseUnaryExpression parses an operand, which may be
+// preceded by "not", "-" or "+".  Each takes in only the
+// operators that bind tighter than it does: "not" binds
+// more loosely than a comparison, so "not a < b" is
+// "not (a < b)", while "-" and "+" bind more loosely
+// than "^" only, so "-2 ^ 2" is "-(2 ^ 2)".
+func (p *Parser) parseUnaryExpression() ast.Expression {
+	var operator, precedence int
+
+	t := p.lexerHandler.Peek()
+	switch t.TypeID {
+	case lexer.Not:
+		operator, precedence = ast.NotOperator, notPrecedence+1
+	case lexer.Dash:
+		operator, precedence = ast.MinusOperator, powerPrecedence
+	case lexer.Plus:
+		operator, precedence = ast.PlusOperator, powerPrecedence
+	default:
+		return p.parsePostfix(p.parsePrimary())
+	}
+	p.lexerHandler.Pop()
+
+	operand := p.parseBinaryExpression(precedence)
+	if operand == nil {
+		return nil
+	}
+
+	// A negative literal needs no work at runtime
+	if operator == ast.MinusOperator && isNumberLiteral(operand) {
+		if r, err := operand.(*ast.Number).Negate(); err == nil {
+			return &r
+		}
+	}
+
+	return ast.NewUnaryExpression(operator, operand, t.Line, t.Col)
+}
+
+// parsePostfix parses any indexes, slices or fields
+// that follow an operand, as in a[i][j], m["k"] or p.x
+func (p *Parser) parsePostfix(exp ast.Expression) ast.Expression {
+	for exp != nil {
+		switch p.lexerHandler.Peek().TypeID {
+		case lexer.LeftBracket:
+			exp = p.parseIndex(exp, p.lexerHandler.Pop())
+		case lexer.Period:
+			t := p.lexerHandler.Pop()
+			name := p.lexerHandler.Pop()
+			if name.TypeID != lexer.Identifier {
+				p.lexerHandler.Push()
+				p.addExpectedErrorForString("Expected field name", name)
+				return nil
+			}
+			exp = ast.NewFieldExpression(exp, name.Value, t.Line, t.Col)
+		default:
+			return exp
+		}
+	}
+
+	return exp
+}
+
+// parseIndex parses an index, a[i], or a slice,
+// a[low:high], after the opening bracket
+func (p *Parser) parseIndex(list ast.Expression, t lexer.Token) ast.Expression {
+	var low, high ast.Expression
+
+	if p.lexerHandler.Peek().TypeID != lexer.Colon {
+		if low = p.parseExpression(); low == nil {
+			return nil
+		}
+	}
+
+	if !p.lexerHandler.Swallow(lexer.Colon) {
+		if !p.swallow(lexer.RightBracket) {
+			return nil
+		}
+		return ast.NewIndexExpression(list, low, t.Line, t.Col)
+	}
+
+	if p.lexerHandler.Peek().TypeID != lexer.RightBracket {
+		if high = p.parseExpression(); high == nil {
+			return nil
+		}
+	}
+
+	if !p.swallow(lexer.RightBracket) {
+		return nil
+	}
+	return ast.NewSliceExpression(list, low, high, t.Line, t.Col)
+}
+
+// parseListLiteral parses the elements of a list
+// after the opening bracket.  A long list can be
+// split over several lines, after the bracket or
+// any comma, and may end with a comma
+func (p *Parser) parseListLiteral(t lexer.Token) ast.Expression {
+	result := ast.NewListLiteral(t.Line, t.Col)
+
+	p.skipNewlines()
+	for p.lexerHandler.Peek().TypeID != lexer.RightBracket {
+		element := p.parseExpression()
+		if element == nil {
+			return nil
+		}
+		result.ElementNodes = append(result.ElementNodes, element)
+
+		p.skipNewlines()
+		if p.lexerHandler.Peek().TypeID == lexer.RightBracket {
+			break
+		}
+		if !p.swallow(lexer.Comma) {
+			return nil
+		}
+		p.skipNewlines()
+	}
+	p.swallow(lexer.RightBracket)
+
+	return result
+}
+
+// parseMapLiteral parses the entries of a map after
+// the opening brace.  Each entry is a key and a value
+// separated by a colon, and like a list the entries
+// can be split over several lines
+func (p *Parser) parseMapLiteral(t lexer.Token) ast.Expression {
+	result := ast.NewMapLiteral(t.Line, t.Col)
+
+	p.skipNewlines()
+	for p.lexerHandler.Peek().TypeID != lexer.RightCurlyBrace {
+		key := p.parseExpression()
+		if key == nil || !p.swallow(lexer.Colon) {
+			return nil
+		}
+
+		value := p.parseExpression()
+		if value == nil {
+			return nil
+		}
+		result.KeyNodes = append(result.KeyNodes, key)
+		result.ValueNodes = append(result.ValueNodes, value)
+
+		p.skipNewlines()
+		if p.lexerHandler.Peek().TypeID == lexer.RightCurlyBrace {
+			break
+		}
+		if !p.swallow(lexer.Comma) {
+			return nil
+		}
+		p.skipNewlines()
+	}
+	p.swallow(lexer.RightCurlyBrace)
+
+	return result
+}
+
+// parseInterpolatedString parses a string with
+// expressions in it, from the lexer's StringStart,
+// StringMiddle and StringEnd tokens for the text
+// around the expressions
+func (p *Parser) parseInterpolatedString(t lexer.Token) ast.Expression {
+	result := ast.NewInterpolatedString(t.Line, t.Col)
+
+	for part := t; ; {
+		if part.Value != "" {
+			result.PartNodes = append(result.PartNodes, ast.NewString(part.Value))
+		}
+		if part.TypeID == lexer.StringEnd {
+			return result
+		}
+
+		exp := p.parseExpression()
+		if exp == nil {
+			p.skipInterpolatedString()
+			return nil
+		}
+		result.PartNodes = append(result.PartNodes, exp)
+
+		if part = p.lexerHandler.Pop(); part.TypeID != lexer.StringMiddle && part.TypeID != lexer.StringEnd {
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expected } after expression in string", part)
+			p.skipInterpolatedString()
+			return nil
+		}
+	}
+}
+
+// skipInterpolatedString skips the rest of a string
+// with an error in one of its expressions, so that
+// parsing can carry on after it
+func (p *Parser) skipInterpolatedString() {
+	for depth := 1; depth > 0; {
+		switch p.lexerHandler.Pop().TypeID {
+		case lexer.StringStart:
+			depth++
+		case lexer.StringEnd:
+			depth--
+		case lexer.Newline, lexer.EndTokenList:
+			p.lexerHandler.Push()
+			return
+		}
+	}
+}
+
+func (p *Parser) skipNewlines() {
+	for p.lexerHandler.Swallow(lexer.Newline) {
+	}
+}
+
+// isNumberLiteral checks whether the
+// expression is a constant number
+func isNumberLiteral(exp ast.Expression) bool {
+	_, isNumber := exp.(*ast.Number)
+	return isNumber
+}
+
+// parsePrimary parses a literal, an interpolated string, a
+// list, a map, a struct, a variable, a function call, a
+// cast or a parenthesized expression
+func (p *Parser) parsePrimary() ast.Expression {
+	t := p.lexerHandler.Pop()
+	switch t.TypeID {
+	case lexer.Integer, lexer.Float:
+		if n := p.number(&t); n != nil {
+			return n
+		}
+		return nil
+	case lexer.String:
+		return ast.NewString(t.Value[1 : len(t.Value)-1])
+	case lexer.StringStart:
+		return p.parseInterpolatedString(t)
+	case lexer.True, lexer.False:
+		return ast.NewBool(t.TypeID == lexer.True)
+	case lexer.LeftBracket:
+		return p.parseListLiteral(t)
+	case lexer.LeftCurlyBrace:
+		return p.parseMapLiteral(t)
+	case lexer.LeftParen:
+		result := p.parseExpression()
+		if result == nil || !p.swallow(lexer.RightParen) {
+			return nil
+		}
+		return result
+	case lexer.IntType, lexer.FloatType, lexer.NumberType:
+		dataType, _ := p.dataType(t)
+		if !p.swallow(lexer.LeftParen) {
+			return nil
+		}
+		exp := p.parseExpression()
+		if exp == nil || !p.swallow(lexer.RightParen) {
+			return nil
+		}
+		return ast.NewCastExpression(dataType, exp, t.Line, t.Col)
+	case lexer.Identifier:
+		if p.lexerHandler.Peek().TypeID == lexer.LeftParen {
+			if call := p.parseCallExpression(t); call != nil {
+				return call
+			}
+			return nil
+		}
+		if p.structNames[t.Value] && p.lexerHandler.Peek().TypeID == lexer.LeftCurlyBrace {
+			return p.parseStructLiteral(t)
+		}
+		return ast.NewIdentifier(t.Value, t.Line, t.Col)
+	default:
+		p.lexerHandler.Push()
+		p.addExpectedErrorForString("Expected expression", t)
+		return nil
+	}
+}
+
+func (p *Parser) number(t *lexer.Token) *ast.Number {
+	var result *ast.Number
+
+	switch t.TypeID {
+	case lexer.Integer:
+		n, err := strconv.ParseInt(t.Value, 10, 64)
+		if err != nil {
+			p.addError(fmt.Errorf("Integer '%s' is too large at line %d:%d", t.Value, t.Line, t.Col))
+			return nil
+		}
+		result = ast.NewIntNumber(n)
+	case lexer.Float:
+		n, _ := strconv.ParseFloat(t.Value, 64)
+		result = ast.NewFloatNumber(n)
+	}
+
+	return result
+}
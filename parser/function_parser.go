@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"github.com/hculpan/kablang/ast"
+	"github.com/hculpan/kablang/lexer"
+)
+
+// parseFunctions parses a program made up of
+// function and struct type declarations
+func (p *Parser) parseFunctions() *ast.Program {
+	result := ast.NewProgram(ast.NewBlock(nil), p.types)
+	p.blockStack.Push(result.BlockNode)
+	defer p.blockStack.Pop()
+
+	done := false
+	for !done {
+		t := p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.Newline:
+			continue
+		case lexer.Hash:
+			p.skipComment()
+		case lexer.Func:
+			if f := p.parseFunction(); f != nil {
+				result.Functions = append(result.Functions, f)
+			}
+		case lexer.Type:
+			if s := p.parseStructDeclaration(); s != nil {
+				result.Structs = append(result.Structs, s)
+			}
+		case lexer.EndTokenList:
+			done = true
+		default:
+			p.addExpectedErrorForString("Expected function declaration", t)
+			p.skipComment()
+		}
+	}
+
+	return result
+}
+
+func (p *Parser) parseFunction() *ast.Function {
+	result := ast.NewFunction()
+
+	nameToken := p.lexerHandler.Pop()
+	if nameToken.TypeID != lexer.Identifier {
+		p.lexerHandler.Push()
+		p.addExpectedErrorForTypeID(lexer.Identifier, nameToken)
+		return nil
+	}
+	result.Name = nameToken.Value
+	result.Line, result.Col = nameToken.Line, nameToken.Col
+
+	if !p.swallow(lexer.LeftParen) {
+		return nil
+	}
+
+	for p.lexerHandler.Peek().TypeID != lexer.RightParen {
+		if len(result.Parameters) > 0 && !p.swallow(lexer.Comma) {
+			return nil
+		}
+
+		t := p.lexerHandler.Pop()
+		if t.TypeID != lexer.Identifier {
+			p.lexerHandler.Push()
+			p.addExpectedErrorForTypeID(lexer.Identifier, t)
+			return nil
+		}
+
+		dataType, ok := p.parseDataType()
+		if !ok {
+			return nil
+		}
+
+		result.Parameters = append(result.Parameters, ast.Parameter{Name: t.Value, DataType: dataType})
+	}
+	p.swallow(lexer.RightParen)
+
+	if p.atDataType() {
+		dataType, ok := p.parseDataType()
+		if !ok {
+			return nil
+		}
+		result.ReturnDataType = dataType
+	}
+
+	if p.lexerHandler.Peek().TypeID != lexer.LeftCurlyBrace {
+		p.addExpectedErrorForTypeID(lexer.LeftCurlyBrace, p.lexerHandler.Peek())
+		return nil
+	}
+	result.BlockNode = p.parseBlock(p.currentBlock())
+
+	return result
+}
+
+func (p *Parser) parseReturnStatement(t lexer.Token) *ast.ReturnStatement {
+	result := ast.NewReturnStatement(t.Line, t.Col)
+
+	switch p.lexerHandler.Peek().TypeID {
+	case lexer.Newline, lexer.RightCurlyBrace, lexer.EndTokenList:
+		return result
+	}
+
+	if result.ExpressionNode = p.parseExpression(); result.ExpressionNode == nil {
+		return nil
+	}
+
+	return result
+}
+
+// parseCallExpression parses a call to the
+// function named by t and its arguments
+func (p *Parser) parseCallExpression(t lexer.Token) *ast.CallExpression {
+	result := ast.NewCallExpression(t.Value, t.Line, t.Col)
+
+	p.swallow(lexer.LeftParen)
+	for p.lexerHandler.Peek().TypeID != lexer.RightParen {
+		if len(result.ArgumentNodes) > 0 && !p.swallow(lexer.Comma) {
+			return nil
+		}
+
+		arg := p.parseExpression()
+		if arg == nil {
+			return nil
+		}
+		result.ArgumentNodes = append(result.ArgumentNodes, arg)
+	}
+	p.swallow(lexer.RightParen)
+
+	return result
+}
+
+// parseDataType parses a data type, which may be a list
+// type such as []number, a map type such as
+// map[string]int, or the name of a struct type,
+// reporting an error if the next tokens are not a data
+// type.  Whether a struct type has been declared is
+// left to the checker
+func (p *Parser) parseDataType() (int, bool) {
+	t := p.lexerHandler.Pop()
+	switch t.TypeID {
+	case lexer.LeftBracket:
+		if !p.swallow(lexer.RightBracket) {
+			return ast.TypeNone, false
+		}
+
+		elementType, ok := p.parseDataType()
+		if !ok {
+			return ast.TypeNone, false
+		}
+		return p.types.ListType(elementType), true
+	case lexer.MapType:
+		if !p.swallow(lexer.LeftBracket) {
+			return ast.TypeNone, false
+		}
+
+		// Only strings can be used as keys
+		if k := p.lexerHandler.Pop(); k.TypeID != lexer.StringType {
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expecting map key type string", k)
+			return ast.TypeNone, false
+		}
+
+		if !p.swallow(lexer.RightBracket) {
+			return ast.TypeNone, false
+		}
+
+		valueType, ok := p.parseDataType()
+		if !ok {
+			return ast.TypeNone, false
+		}
+		return p.types.MapType(valueType), true
+	case lexer.Identifier:
+		return p.types.StructType(t.Value), true
+	}
+
+	dataType, ok := p.dataType(t)
+	if !ok {
+		p.lexerHandler.Push()
+		p.addExpectedErrorForString("Expecting data type indicator", t)
+	}
+	return dataType, ok
+}
+
+// atDataType checks whether the next token starts a data type
+func (p *Parser) atDataType() bool {
+	t := p.lexerHandler.Peek()
+	_, ok := p.dataType(t)
+	switch t.TypeID {
+	case lexer.LeftBracket, lexer.MapType, lexer.Identifier:
+		return true
+	}
+	return ok
+}
+
+// dataType converts a type token into a data type
+func (p *Parser) dataType(t lexer.Token) (int, bool) {
+	switch t.TypeID {
+	case lexer.StringType:
+		return ast.TypeString, true
+	case lexer.NumberType:
+		return ast.TypeNumber, true
+	case lexer.BoolType:
+		return ast.TypeBool, true
+	case lexer.IntType:
+		return ast.TypeInt, true
+	case lexer.FloatType:
+		return ast.TypeFloat, true
+	}
+
+	return ast.TypeNone, false
+}
@@ -64,3 +64,15 @@ func (l *LexerHandler) Swallow(typeID lexer.TokenType) bool {
 	}
 	return true
 }
+
+// Mark returns the current position in the token
+// list so that it can later be restored with Reset
+func (l *LexerHandler) Mark() int {
+	return l.currTokenIndex
+}
+
+// Reset restores the position previously
+// returned by Mark
+func (l *LexerHandler) Reset(mark int) {
+	l.currTokenIndex = mark
+}
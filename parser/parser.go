@@ -12,6 +12,12 @@ type Parser struct {
 	errors       []error
 	lexerHandler *LexerHandler
 	blockStack   *ast.BlockStack
+	loopDepth    int
+	structNames  map[string]bool
+
+	// types holds the data types of the
+	// program being parsed
+	types *ast.TypeTable
 }
 
 // NewParser creates a new parser and returns
@@ -27,9 +33,13 @@ func (p *Parser) Parse(lines []string) (*ast.Program, []error) {
 	p.errors = []error{}
 	p.lexerHandler = NewLexerHandler(lines)
 	if len(p.lexerHandler.Errors) != 0 {
-		fmt.Println()
 		return nil, []error{p.lexerHandler.Errors[0]}
 	}
+	p.scanStructNames()
+
+	// Each program has its own types, so the structs
+	// of one program can't be used by another
+	p.types = ast.NewTypeTable()
 
 	result := p.parseProgram()
 
@@ -42,8 +52,45 @@ func (p *Parser) printTokens() {
 	}
 }
 
+// parseProgram parses either a single top-level
+// block, or a series of function declarations
 func (p *Parser) parseProgram() *ast.Program {
-	return ast.NewProgram(p.parseBlock(nil))
+	for {
+		t := p.lexerHandler.Pop()
+		if t.TypeID == lexer.Hash {
+			p.skipComment()
+		} else if t.TypeID != lexer.Newline {
+			p.lexerHandler.Push()
+			break
+		}
+	}
+
+	if p.lexerHandler.Peek().TypeID == lexer.LeftCurlyBrace {
+		result := ast.NewProgram(p.parseBlock(nil), p.types)
+		p.parseEndOfProgram()
+		return result
+	}
+
+	return p.parseFunctions()
+}
+
+// parseEndOfProgram checks that nothing but newlines
+// and comments follows a program's top-level block
+func (p *Parser) parseEndOfProgram() {
+	for {
+		t := p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.Newline:
+		case lexer.Hash:
+			p.skipComment()
+		case lexer.EndTokenList:
+			return
+		default:
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expected end of program after top-level block", t)
+			return
+		}
+	}
 }
 
 func (p *Parser) parseBlock(parent *ast.Block) *ast.Block {
@@ -79,36 +126,58 @@ func (p *Parser) parseStatements() *ast.Statements {
 		case lexer.EndTokenList:
 			done = true
 		case lexer.Hash:
-			for {
-				t = p.lexerHandler.Pop()
-				if t.TypeID == lexer.Newline || t.TypeID == lexer.EndTokenList {
-					break
-				}
-			}
+			p.skipComment()
 		case lexer.Var:
-			a, err := p.parseVarStatement()
-			if err == nil {
+			if a := p.parseVarDeclaration(t); a != nil {
 				stmt = a
-				symbol := stmt.(*ast.VarStatement).SymbolNode
-				if _, exists := p.currentBlock().Symbols.GetLocal(symbol.GetName()); exists {
-					p.addError(fmt.Errorf("Redefinition of variable '%s' at %d:%d", symbol.GetName(), t.Line, t.Col))
-					return nil
-				}
-				p.currentBlock().AddSymbol(symbol)
 			}
 			p.swallow(lexer.Newline)
 		case lexer.Identifier:
-			stmt = p.parseAssignStatement(&t)
+			if p.lexerHandler.Peek().TypeID == lexer.LeftParen {
+				if call := p.parseCallExpression(t); call != nil {
+					stmt = call
+				}
+			} else if a := p.parseAssignStatement(&t); a != nil {
+				stmt = a
+			}
+			p.swallow(lexer.Newline)
+		case lexer.Return:
+			if r := p.parseReturnStatement(t); r != nil {
+				stmt = r
+			}
 			p.swallow(lexer.Newline)
 		case lexer.Print:
-			p.lexerHandler.Push()
-			stmt = p.parsePrintStatement(false)
+			if s := p.parsePrintStatement(t); s != nil {
+				stmt = s
+			}
 			if !p.lexerHandler.Swallow(lexer.Newline) {
 				p.addExpectedErrorForTypeID(lexer.Newline, t)
 			}
 		case lexer.Println:
-			p.lexerHandler.Push()
-			stmt = p.parsePrintStatement(true)
+			if s := p.parsePrintStatement(t); s != nil {
+				stmt = s
+			}
+			p.swallow(lexer.Newline)
+		case lexer.If:
+			if s := p.parseIfStatement(t); s != nil {
+				stmt = s
+			}
+		case lexer.For:
+			if p.isForIn() {
+				if s := p.parseForInStatement(t); s != nil {
+					stmt = s
+				}
+			} else if s := p.parseForStatement(t); s != nil {
+				stmt = s
+			}
+		case lexer.Break, lexer.Continue:
+			if p.loopDepth == 0 {
+				p.addError(fmt.Errorf("'%s' outside of loop at line %d:%d", t.Value, t.Line, t.Col))
+			} else if t.TypeID == lexer.Break {
+				stmt = ast.NewBreakStatement()
+			} else {
+				stmt = ast.NewContinueStatement()
+			}
 			p.swallow(lexer.Newline)
 		default:
 			p.addError(fmt.Errorf("Unexpected token: '%s' at line %d:%d", t.Value, t.Line, t.Col))
@@ -122,159 +191,278 @@ func (p *Parser) parseStatements() *ast.Statements {
 	return ast.NewStatements(stmts)
 }
 
-func (p *Parser) parseAssignStatement(t *lexer.Token) *ast.AssignStatement {
-	if t.TypeID != lexer.Identifier {
+// skipComment consumes the remainder of the line
+func (p *Parser) skipComment() {
+	for {
+		t := p.lexerHandler.Pop()
+		if t.TypeID == lexer.Newline || t.TypeID == lexer.EndTokenList {
+			break
+		}
+	}
+}
+
+func (p *Parser) parseIfStatement(t lexer.Token) *ast.IfStatement {
+	result := ast.NewIfStatement(p.parseExpression(), nil, t.Line, t.Col)
+
+	if p.lexerHandler.Peek().TypeID != lexer.LeftCurlyBrace {
+		p.addExpectedErrorForTypeID(lexer.LeftCurlyBrace, p.lexerHandler.Peek())
 		return nil
 	}
+	result.BlockNode = p.parseBlock(p.currentBlock())
 
-	p.swallow(lexer.Equals)
-
-	if symbol, exists := p.currentBlock().Symbols.GetLocal(t.Value); exists {
-		stmt := ast.NewAssignStatement(symbol)
-		switch symbol.GetDataType() {
-		case ast.TypeString:
-			stmt.ExpressionNode = p.parseStringExpression()
-			return stmt
-		case ast.TypeNumber:
-			stmt.ExpressionNode = p.parseNumExpression()
-			return stmt
+	if p.lexerHandler.Swallow(lexer.Else) {
+		t := p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.If:
+			if elseIf := p.parseIfStatement(t); elseIf != nil {
+				result.ElseNode = elseIf
+			}
+		case lexer.LeftCurlyBrace:
+			p.lexerHandler.Push()
+			result.ElseNode = p.parseBlock(p.currentBlock())
 		default:
-			p.addError(fmt.Errorf("Unsupported data type for variable assignment at line %d:%d", t.Line, t.Col))
+			p.lexerHandler.Push()
+			p.addExpectedErrorForTypeID(lexer.LeftCurlyBrace, t)
 			return nil
 		}
 	}
 
-	p.addError(fmt.Errorf("Assignment without declaration for variable '%s' at line %d:%d", t.Value, t.Line, t.Col))
-	return nil
+	return result
 }
 
-func (p *Parser) parseVarStatement() (*ast.VarStatement, error) {
-	nameToken := p.lexerHandler.Pop()
-	if nameToken.TypeID != lexer.Identifier {
-		p.lexerHandler.Push()
-		p.addExpectedErrorForTypeID(lexer.Identifier, nameToken)
-		return nil, fmt.Errorf("")
-	}
+func (p *Parser) parseForStatement(t lexer.Token) *ast.ForStatement {
+	result := ast.NewForStatement(ast.NewBlock(p.currentBlock()), t.Line, t.Col)
+	p.blockStack.Push(result.ScopeNode)
+	defer p.blockStack.Pop()
 
-	typeToken := p.lexerHandler.Pop()
-	if typeToken.TypeID != lexer.StringType && typeToken.TypeID != lexer.NumberType {
-		p.lexerHandler.Push()
-		p.addExpectedErrorForString("Expecting data type indicator", typeToken)
-		return nil, fmt.Errorf("")
-	}
-
-	var result *ast.VarStatement = nil
+	if p.isSemicolonBeforeBlock() {
+		t := p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.Semicolon:
+			p.lexerHandler.Push()
+		case lexer.Var:
+			if stmt := p.parseVarDeclaration(t); stmt != nil {
+				result.InitNode = stmt
+			}
+		case lexer.Identifier:
+			if stmt := p.parseAssignStatement(&t); stmt != nil {
+				result.InitNode = stmt
+			}
+		default:
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expected loop initializer", t)
+			return nil
+		}
+		p.swallow(lexer.Semicolon)
 
-	switch typeToken.Value {
-	case "string":
-		result = ast.NewVarStatement(nameToken.Value, ast.TypeString)
-	case "number":
-		result = ast.NewVarStatement(nameToken.Value, ast.TypeNumber)
-	default:
-		p.addError(fmt.Errorf("Invalid data type: %s", typeToken.Value))
-		return nil, fmt.Errorf("")
-	}
+		if p.lexerHandler.Peek().TypeID != lexer.Semicolon {
+			result.ConditionNode = p.parseExpression()
+		}
+		p.swallow(lexer.Semicolon)
 
-	t := p.lexerHandler.Peek()
-	if t.TypeID == lexer.Equals {
-		p.swallow(lexer.Equals)
-		switch result.SymbolNode.GetDataType() {
-		case ast.TypeString:
-			result.ExpressionNode = p.parseStringExpression()
-		case ast.TypeNumber:
-			result.ExpressionNode = p.parseNumExpression()
+		t = p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.LeftCurlyBrace:
+			p.lexerHandler.Push()
+		case lexer.Identifier:
+			if stmt := p.parseAssignStatement(&t); stmt != nil {
+				result.StepNode = stmt
+			}
 		default:
-			p.addError(fmt.Errorf("Invalid data type assigned to variable '%s' of type '%s' at %d:%d",
-				result.SymbolNode.GetName(), ast.GetTypeName(result.SymbolNode.GetDataType()), t.Line, t.Col))
-			return nil, fmt.Errorf("")
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expected loop step", t)
+			return nil
 		}
+	} else if p.lexerHandler.Peek().TypeID != lexer.LeftCurlyBrace {
+		result.ConditionNode = p.parseExpression()
 	}
 
-	return result, nil
+	if p.lexerHandler.Peek().TypeID != lexer.LeftCurlyBrace {
+		p.addExpectedErrorForTypeID(lexer.LeftCurlyBrace, p.lexerHandler.Peek())
+		return nil
+	}
+
+	p.loopDepth++
+	result.BlockNode = p.parseBlock(result.ScopeNode)
+	p.loopDepth--
+
+	return result
 }
 
-func (p *Parser) parsePrintStatement(endline bool) *ast.PrintStatement {
-	if endline {
-		p.swallow(lexer.Println)
-	} else {
-		p.swallow(lexer.Print)
+// isForIn checks whether a "for" statement loops over
+// a collection, which starts with a variable name
+// followed by "in" or a comma
+func (p *Parser) isForIn() bool {
+	i := p.lexerHandler.Mark()
+	if i+1 >= len(p.lexerHandler.tokens) || p.lexerHandler.tokens[i].TypeID != lexer.Identifier {
+		return false
 	}
 
-	t := p.lexerHandler.Peek()
-	switch t.TypeID {
-	case lexer.Newline:
-		return ast.NewEmptyPrintStatement(endline)
-	case lexer.EndTokenList:
-		return ast.NewEmptyPrintStatement(endline)
-	case lexer.String:
-		return ast.NewStringPrintStatement(p.parseStringExpression(), endline)
-	case lexer.Integer, lexer.Float, lexer.Dash, lexer.LeftParen:
-		return ast.NewNumPrintStatement(p.parseNumExpression(), endline)
-	case lexer.Identifier:
-		if symbol, exists := p.currentBlock().Symbols.Get(t.Value); exists {
-			switch symbol.GetDataType() {
-			case ast.TypeString:
-				return ast.NewStringPrintStatement(p.parseStringExpression(), endline)
-			case ast.TypeNumber:
-				return ast.NewNumPrintStatement(p.parseNumExpression(), endline)
-			default:
-				p.addError(fmt.Errorf("Invalid data type for variable '%s' at %d:%d", t.Value, t.Line, t.Col))
-				return nil
-			}
-		} else {
-			p.addError(fmt.Errorf("Undeclared variable '%s' at %d:%d", t.Value, t.Line, t.Col))
+	next := p.lexerHandler.tokens[i+1].TypeID
+	return next == lexer.In || next == lexer.Comma
+}
+
+// parseForInStatement parses a loop over a collection,
+// as in "for i, v in values".  The checker declares the
+// loop variables, once it knows the type of the values
+func (p *Parser) parseForInStatement(t lexer.Token) *ast.ForInStatement {
+	result := ast.NewForInStatement(ast.NewBlock(p.currentBlock()), t.Line, t.Col)
+	p.blockStack.Push(result.ScopeNode)
+	defer p.blockStack.Pop()
+
+	name := p.lexerHandler.Pop()
+	if p.lexerHandler.Swallow(lexer.Comma) {
+		result.KeyName = name.Value
+		if name = p.lexerHandler.Pop(); name.TypeID != lexer.Identifier {
+			p.lexerHandler.Push()
+			p.addExpectedErrorForTypeID(lexer.Identifier, name)
 			return nil
 		}
 	}
+	result.ValueName = name.Value
+
+	if !p.swallow(lexer.In) {
+		return nil
+	}
 
-	return nil
+	if result.CollectionNode = p.parseExpression(); result.CollectionNode == nil {
+		return nil
+	}
+
+	if p.lexerHandler.Peek().TypeID != lexer.LeftCurlyBrace {
+		p.addExpectedErrorForTypeID(lexer.LeftCurlyBrace, p.lexerHandler.Peek())
+		return nil
+	}
+
+	p.loopDepth++
+	result.BlockNode = p.parseBlock(result.ScopeNode)
+	p.loopDepth--
+
+	return result
 }
 
-func (p *Parser) parseString() ast.StringValue {
-	var result ast.StringValue
-
-	t := p.lexerHandler.Pop()
-	switch t.TypeID {
-	case lexer.Identifier:
-		if symbol, exists := p.currentBlock().Symbols.Get(t.Value); exists {
-			switch symbol.(type) {
-			case *ast.StringSymbol:
-				result = symbol.(*ast.StringSymbol)
-			default:
-				p.addError(fmt.Errorf("Cannot assign type %T to variable '%s' of type string", symbol, t.Value))
-				return nil
-			}
-		} else {
-			p.addError(fmt.Errorf("Undeclared variable '%s' at %d:%d", t.Value, t.Line, t.Col))
-			return nil
+// isSemicolonBeforeBlock scans ahead to determine
+// whether a "for" statement has the three part form
+func (p *Parser) isSemicolonBeforeBlock() bool {
+	for i := p.lexerHandler.Mark(); i < len(p.lexerHandler.tokens); i++ {
+		switch p.lexerHandler.tokens[i].TypeID {
+		case lexer.Semicolon:
+			return true
+		case lexer.LeftCurlyBrace, lexer.Newline, lexer.EndTokenList:
+			return false
 		}
-	case lexer.String:
-		result = ast.NewString(t.Value)
 	}
 
-	return result
+	return false
 }
 
-func (p *Parser) parseStringExpression() *ast.StringExpression {
-	var result *ast.StringExpression = ast.NewStringExpression()
+// parseAssignStatement parses an assignment to a
+// variable or to an element of a list, as in a[i] = x
+func (p *Parser) parseAssignStatement(t *lexer.Token) *ast.AssignStatement {
+	if t.TypeID != lexer.Identifier {
+		return nil
+	}
 
-	result.StringNode = p.parseString()
+	target := p.parsePostfix(ast.NewIdentifier(t.Value, t.Line, t.Col))
+	if target == nil {
+		return nil
+	}
 
-	t := p.lexerHandler.Pop()
-	switch t.TypeID {
-	case lexer.Plus:
-		t2 := p.lexerHandler.Peek()
-		if t2.TypeID != lexer.String && t2.TypeID != lexer.Identifier {
-			p.addExpectedErrorForTypeID(lexer.String, t2)
-		}
-		result.StringExpressionNode = p.parseStringExpression()
+	op := p.lexerHandler.Pop()
+	if op.TypeID != lexer.Equals {
+		return p.parseCompoundAssignStatement(t, target, op)
+	}
+
+	stmt := ast.NewAssignStatement(target, ast.PlainAssignment, t.Line, t.Col)
+	if stmt.ExpressionNode = p.parseExpression(); stmt.ExpressionNode == nil {
+		return nil
+	}
+
+	return stmt
+}
+
+// parseCompoundAssignStatement parses the compound
+// assignments (+=, -=, *=, /=) and the increment and
+// decrement statements (++, --).  These are desugared
+// into a plain assignment, so "x -= a + b" becomes
+// "x = x - (a + b)" and "x++" becomes "x = x + 1".
+// The left operand is a TargetValue rather than the
+// target itself, so an index in the target such as
+// "l[g()] += 1" is only evaluated once
+func (p *Parser) parseCompoundAssignStatement(t *lexer.Token, target ast.Expression, op lexer.Token) *ast.AssignStatement {
+	var operator int
+	switch op.TypeID {
+	case lexer.PlusEquals, lexer.DoublePlus:
+		operator = ast.PlusOperator
+	case lexer.MinusEquals, lexer.DoubleMinus:
+		operator = ast.MinusOperator
+	case lexer.MultEquals:
+		operator = ast.MultOperator
+	case lexer.DivEquals:
+		operator = ast.DivOperator
 	default:
 		p.lexerHandler.Push()
+		p.addExpectedErrorForTypeID(lexer.Equals, op)
+		return nil
+	}
+
+	kind := ast.CompoundAssignment
+	var operand ast.Expression
+	if op.TypeID == lexer.DoublePlus || op.TypeID == lexer.DoubleMinus {
+		kind = ast.IncrementAssignment
+		operand = ast.NewIntNumber(1)
+	} else if operand = p.parseExpression(); operand == nil {
+		return nil
+	}
+
+	stmt := ast.NewAssignStatement(target, kind, t.Line, t.Col)
+	stmt.ExpressionNode = ast.NewBinaryExpression(operator, ast.NewTargetValue(target), operand, op.Line, op.Col)
+
+	return stmt
+}
+
+// parseVarDeclaration parses a var statement.  The
+// checker adds the new symbol to its block
+func (p *Parser) parseVarDeclaration(t lexer.Token) *ast.VarStatement {
+	nameToken := p.lexerHandler.Pop()
+	if nameToken.TypeID != lexer.Identifier {
+		p.lexerHandler.Push()
+		p.addExpectedErrorForTypeID(lexer.Identifier, nameToken)
+		return nil
+	}
+
+	dataType, ok := p.parseDataType()
+	if !ok {
+		return nil
+	}
+
+	result := ast.NewVarStatement(ast.NewSymbol(nameToken.Value, dataType, p.types), nameToken.Line, nameToken.Col)
+
+	if p.lexerHandler.Swallow(lexer.Equals) {
+		if result.ExpressionNode = p.parseExpression(); result.ExpressionNode == nil {
+			return nil
+		}
 	}
 
 	return result
 }
 
+func (p *Parser) parsePrintStatement(t lexer.Token) *ast.PrintStatement {
+	endline := t.TypeID == lexer.Println
+
+	switch p.lexerHandler.Peek().TypeID {
+	case lexer.Newline, lexer.EndTokenList:
+		return ast.NewPrintStatement(nil, endline, t.Line, t.Col)
+	}
+
+	exp := p.parseExpression()
+	if exp == nil {
+		return nil
+	}
+
+	return ast.NewPrintStatement(exp, endline, t.Line, t.Col)
+}
+
 func (p *Parser) swallow(typeID lexer.TokenType) bool {
 	if !p.lexerHandler.Swallow(typeID) {
 		p.addExpectedErrorForTypeID(typeID, p.lexerHandler.Peek())
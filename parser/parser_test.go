@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserTopLevelBlock(t *testing.T) {
+	p := NewParser()
+	program, errs := p.Parse([]string{"{", "println 1", "}", "# done", ""})
+	if program == nil || len(errs) != 0 {
+		t.Log(errs)
+		t.Fail()
+	}
+}
+
+func TestParserTextAfterTopLevelBlock(t *testing.T) {
+	p := NewParser()
+	_, errs := p.Parse([]string{"{", "println 1", "}", "", "func main() {", "}"})
+	if len(errs) != 1 {
+		t.Logf("Expected 1 error, found %d: %v", len(errs), errs)
+		t.Fail()
+	} else if !strings.Contains(errs[0].Error(), "found Func at line 5:1") {
+		t.Log(errs[0])
+		t.Fail()
+	}
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"github.com/hculpan/kablang/ast"
+	"github.com/hculpan/kablang/lexer"
+)
+
+// scanStructNames finds the names of all the struct
+// types declared in the program before it is parsed.
+// A name followed by "{" is only a struct literal if
+// it names a struct type, as otherwise it could be a
+// variable followed by a block, as in "if done {"
+func (p *Parser) scanStructNames() {
+	p.structNames = map[string]bool{}
+
+	tokens := p.lexerHandler.tokens
+	inComment := false
+	for i := 0; i+1 < len(tokens); i++ {
+		switch tokens[i].TypeID {
+		case lexer.Hash:
+			inComment = true
+		case lexer.Newline:
+			inComment = false
+		case lexer.Type:
+			if !inComment && tokens[i+1].TypeID == lexer.Identifier {
+				p.structNames[tokens[i+1].Value] = true
+			}
+		}
+	}
+}
+
+// parseStructDeclaration parses the declaration of a
+// struct type after the "type" keyword.  The fields are
+// separated by newlines or semicolons
+func (p *Parser) parseStructDeclaration() *ast.StructDeclaration {
+	nameToken := p.lexerHandler.Pop()
+	if nameToken.TypeID != lexer.Identifier {
+		p.lexerHandler.Push()
+		p.addExpectedErrorForTypeID(lexer.Identifier, nameToken)
+		return nil
+	}
+	result := ast.NewStructDeclaration(nameToken.Value, p.types.StructType(nameToken.Value), nameToken.Line, nameToken.Col)
+
+	if !p.swallow(lexer.Struct) || !p.swallow(lexer.LeftCurlyBrace) {
+		return nil
+	}
+
+	for {
+		t := p.lexerHandler.Pop()
+		switch t.TypeID {
+		case lexer.Newline, lexer.Semicolon:
+			continue
+		case lexer.Hash:
+			p.skipComment()
+			continue
+		case lexer.RightCurlyBrace:
+			return result
+		case lexer.Identifier:
+			dataType, ok := p.parseDataType()
+			if !ok {
+				return nil
+			}
+			result.Fields = append(result.Fields, ast.Field{Name: t.Value, DataType: dataType})
+		default:
+			p.lexerHandler.Push()
+			p.addExpectedErrorForString("Expected field declaration", t)
+			return nil
+		}
+
+		switch p.lexerHandler.Peek().TypeID {
+		case lexer.Newline, lexer.Semicolon, lexer.RightCurlyBrace:
+		default:
+			p.addExpectedErrorForTypeID(lexer.Semicolon, p.lexerHandler.Peek())
+			return nil
+		}
+	}
+}
+
+// parseStructLiteral parses the fields of a struct
+// literal, such as Point{x: 1, y: 2}, after its
+// type name.  Like a map, the fields can be split
+// over several lines
+func (p *Parser) parseStructLiteral(t lexer.Token) ast.Expression {
+	result := ast.NewStructLiteral(p.types.StructType(t.Value), t.Line, t.Col)
+	p.swallow(lexer.LeftCurlyBrace)
+
+	p.skipNewlines()
+	for p.lexerHandler.Peek().TypeID != lexer.RightCurlyBrace {
+		name := p.lexerHandler.Pop()
+		if name.TypeID != lexer.Identifier {
+			p.lexerHandler.Push()
+			p.addExpectedErrorForTypeID(lexer.Identifier, name)
+			return nil
+		}
+
+		if !p.swallow(lexer.Colon) {
+			return nil
+		}
+
+		value := p.parseExpression()
+		if value == nil {
+			return nil
+		}
+		result.FieldNames = append(result.FieldNames, name.Value)
+		result.ValueNodes = append(result.ValueNodes, value)
+
+		p.skipNewlines()
+		if p.lexerHandler.Peek().TypeID == lexer.RightCurlyBrace {
+			break
+		}
+		if !p.swallow(lexer.Comma) {
+			return nil
+		}
+		p.skipNewlines()
+	}
+	p.swallow(lexer.RightCurlyBrace)
+
+	return result
+}
@@ -0,0 +1,31 @@
+package system
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// initConversionFunctions loads the functions that
+// convert between numbers and strings
+func initConversionFunctions() {
+	// str formats a number the same way print does
+	NewSystemFunction("str", numParams("n"), ast.TypeString, func(args []interface{}) interface{} {
+		return args[0].(ast.NumberValue).ToString()
+	})
+
+	// num accepts anything that is a valid number
+	// literal, with an optional sign
+	NewSystemFunction("num", strParams("s"), ast.TypeNumber, func(args []interface{}) interface{} {
+		s := strings.TrimSpace(args[0].(string))
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil && !strings.ContainsAny(s, "eEnNxX") {
+			return f
+		}
+		return fmt.Errorf("Invalid number '%s'", args[0].(string))
+	})
+}
@@ -0,0 +1,68 @@
+package system
+
+import (
+	"github.com/hculpan/kablang/ast"
+)
+
+// initListFunctions loads the functions that work on
+// lists.  These accept lists of any type, so they work
+// out their types from their arguments.
+func initListFunctions() {
+	// len counts the elements of a list, the entries
+	// of a map, or the characters of a string
+	length := NewSystemFunction("len", anyParams("value"), ast.TypeInt, func(args []interface{}) interface{} {
+		switch args[0].(type) {
+		case *ast.List:
+			return args[0].(*ast.List).Len()
+		case *ast.Map:
+			return args[0].(*ast.Map).Len()
+		}
+		return len([]rune(args[0].(string)))
+	})
+	length.ResolveTypes = func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) != 1 || (argTypes[0] != ast.TypeString &&
+			!types.IsListType(argTypes[0]) && !types.IsMapType(argTypes[0])) {
+			return nil, ast.TypeNone, false
+		}
+		return []ast.Parameter{{Name: "value", DataType: argTypes[0]}}, ast.TypeInt, true
+	}
+
+	// append adds a value to the end of a list,
+	// changing the list rather than making a new one
+	appendFunction := NewSystemFunction("append", anyParams("list", "value"), ast.TypeNone, func(args []interface{}) interface{} {
+		args[0].(*ast.List).Append(toValue(args[1]))
+		return nil
+	})
+	appendFunction.ResolveTypes = func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) != 2 || !types.IsListType(argTypes[0]) {
+			return nil, ast.TypeNone, false
+		}
+		return []ast.Parameter{
+			{Name: "list", DataType: argTypes[0]},
+			{Name: "value", DataType: types.ElementType(argTypes[0])},
+		}, ast.TypeNone, true
+	}
+}
+
+// toValue converts an argument back to the value it
+// was before it was passed in, as lists hold values
+func toValue(arg interface{}) interface{} {
+	switch arg.(type) {
+	case string:
+		return ast.NewString(arg.(string))
+	case bool:
+		return ast.NewBool(arg.(bool))
+	}
+
+	return arg
+}
+
+// anyParams creates a list of parameters
+// that can be of more than one type
+func anyParams(names ...string) []ast.Parameter {
+	result := make([]ast.Parameter, len(names))
+	for i, n := range names {
+		result[i] = ast.Parameter{Name: n, DataType: ast.TypeNone}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package system
+
+import (
+	"github.com/hculpan/kablang/ast"
+)
+
+// initMapFunctions loads the functions that work on
+// maps.  Like the list functions, they accept maps of
+// any type and work out their types from their arguments.
+func initMapFunctions() {
+	has := NewSystemFunction("has", anyParams("map", "key"), ast.TypeBool, func(args []interface{}) interface{} {
+		return args[0].(*ast.Map).Has(args[1].(string))
+	})
+	has.ResolveTypes = mapAndKeyTypes(ast.TypeBool)
+
+	// delete does nothing if the key is not in the map
+	deleteFunction := NewSystemFunction("delete", anyParams("map", "key"), ast.TypeNone, func(args []interface{}) interface{} {
+		args[0].(*ast.Map).Delete(args[1].(string))
+		return nil
+	})
+	deleteFunction.ResolveTypes = mapAndKeyTypes(ast.TypeNone)
+
+	// keys returns a new list of the keys, in sorted order
+	keys := NewSystemFunction("keys", anyParams("map"), ast.TypeStringList, func(args []interface{}) interface{} {
+		keys := args[0].(*ast.Map).Keys()
+		elements := make([]interface{}, len(keys))
+		for i, k := range keys {
+			elements[i] = ast.NewString(k)
+		}
+		return ast.NewList(ast.TypeStringList, elements)
+	})
+	keys.ResolveTypes = func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) != 1 || !types.IsMapType(argTypes[0]) {
+			return nil, ast.TypeNone, false
+		}
+		return []ast.Parameter{{Name: "map", DataType: argTypes[0]}}, ast.TypeStringList, true
+	}
+}
+
+// mapAndKeyTypes resolves the types of a function
+// that takes a map and one of its keys
+func mapAndKeyTypes(returnType int) ast.SystemFunctionTypes {
+	return func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) != 2 || !types.IsMapType(argTypes[0]) {
+			return nil, ast.TypeNone, false
+		}
+		return []ast.Parameter{
+			{Name: "map", DataType: argTypes[0]},
+			{Name: "key", DataType: ast.TypeString},
+		}, returnType, true
+	}
+}
@@ -0,0 +1,183 @@
+package system
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// initMathFunctions loads the math functions.  Functions
+// whose result is always a whole number, such as floor()
+// and sign(), are declared to return an int, and those
+// that always give a float, such as sqrt(), a float.
+// abs(), min() and max() return the type they were given,
+// or for two different types the type + would give them,
+// and pow() returns the type ^ would.
+func initMathFunctions() {
+	abs := NewSystemFunction("abs", numParams("n"), ast.TypeNumber, func(args []interface{}) interface{} {
+		n := args[0].(ast.NumberValue)
+		if n.GetNumberType() == ast.IntType {
+			if v := n.GetIntValue(); v == math.MinInt64 {
+				return ast.ErrIntegerOverflow
+			} else if v < 0 {
+				return -v
+			}
+			return n
+		}
+		return math.Abs(n.GetFloatValue())
+	})
+	abs.ResolveTypes = operatorTypes(ast.PlusOperator, "n")
+
+	NewSystemFunction("sign", numParams("n"), ast.TypeInt, func(args []interface{}) interface{} {
+		v := args[0].(ast.NumberValue).GetFloatValue()
+		if v < 0 {
+			return -1
+		} else if v > 0 {
+			return 1
+		}
+		return 0
+	})
+
+	NewSystemFunction("floor", numParams("n"), ast.TypeInt, roundingFunction(math.Floor))
+	NewSystemFunction("ceil", numParams("n"), ast.TypeInt, roundingFunction(math.Ceil))
+	NewSystemFunction("round", numParams("n"), ast.TypeInt, roundingFunction(math.Round))
+	NewSystemFunction("trunc", numParams("n"), ast.TypeInt, roundingFunction(math.Trunc))
+
+	min := NewSystemFunction("min", numParams("a", "b"), ast.TypeNumber, func(args []interface{}) interface{} {
+		a, b := args[0].(ast.NumberValue), args[1].(ast.NumberValue)
+		if b.Compare(a) < 0 {
+			return b
+		}
+		return a
+	})
+	min.ResolveTypes = operatorTypes(ast.PlusOperator, "a", "b")
+
+	max := NewSystemFunction("max", numParams("a", "b"), ast.TypeNumber, func(args []interface{}) interface{} {
+		a, b := args[0].(ast.NumberValue), args[1].(ast.NumberValue)
+		if b.Compare(a) > 0 {
+			return b
+		}
+		return a
+	})
+	max.ResolveTypes = operatorTypes(ast.PlusOperator, "a", "b")
+
+	// pow() uses the same arithmetic as the ^ operator,
+	// so the two always agree
+	pow := NewSystemFunction("pow", numParams("base", "exponent"), ast.TypeNumber, func(args []interface{}) interface{} {
+		result, err := args[0].(ast.NumberValue).Pow(args[1].(ast.NumberValue))
+		if err != nil {
+			return err
+		}
+		return result
+	})
+	pow.ResolveTypes = operatorTypes(ast.PowerOperator, "base", "exponent")
+
+	NewSystemFunction("sqrt", numParams("n"), ast.TypeFloat, func(args []interface{}) interface{} {
+		v := args[0].(ast.NumberValue).GetFloatValue()
+		if v < 0 {
+			return fmt.Errorf("Square root of negative number %s", args[0].(ast.NumberValue).ToString())
+		}
+		return math.Sqrt(v)
+	})
+
+	NewSystemFunction("hypot", numParams("x", "y"), ast.TypeFloat, func(args []interface{}) interface{} {
+		return math.Hypot(args[0].(ast.NumberValue).GetFloatValue(), args[1].(ast.NumberValue).GetFloatValue())
+	})
+
+	NewSystemFunction("log", numParams("n"), ast.TypeFloat, logFunction(math.Log))
+	NewSystemFunction("log10", numParams("n"), ast.TypeFloat, logFunction(math.Log10))
+	NewSystemFunction("log2", numParams("n"), ast.TypeFloat, logFunction(math.Log2))
+	NewSystemFunction("exp", numParams("n"), ast.TypeFloat, floatFunction(math.Exp))
+
+	NewSystemFunction("sin", numParams("n"), ast.TypeFloat, floatFunction(math.Sin))
+	NewSystemFunction("cos", numParams("n"), ast.TypeFloat, floatFunction(math.Cos))
+	NewSystemFunction("tan", numParams("n"), ast.TypeFloat, floatFunction(math.Tan))
+	NewSystemFunction("asin", numParams("n"), ast.TypeFloat, floatFunction(math.Asin))
+	NewSystemFunction("acos", numParams("n"), ast.TypeFloat, floatFunction(math.Acos))
+	NewSystemFunction("atan", numParams("n"), ast.TypeFloat, floatFunction(math.Atan))
+
+	NewSystemFunction("atan2", numParams("y", "x"), ast.TypeFloat, func(args []interface{}) interface{} {
+		return math.Atan2(args[0].(ast.NumberValue).GetFloatValue(), args[1].(ast.NumberValue).GetFloatValue())
+	})
+
+	NewSystemFunction("pi", numParams(), ast.TypeFloat, func([]interface{}) interface{} {
+		return math.Pi
+	})
+
+	NewSystemFunction("e", numParams(), ast.TypeFloat, func([]interface{}) interface{} {
+		return math.E
+	})
+}
+
+// numParams creates a list of number parameters
+func numParams(names ...string) []ast.Parameter {
+	result := make([]ast.Parameter, len(names))
+	for i, n := range names {
+		result[i] = ast.Parameter{Name: n, DataType: ast.TypeNumber}
+	}
+	return result
+}
+
+// operatorTypes resolves the types of a math function
+// that takes one or two numbers of any type.  With one,
+// the result has the same type, and with two it has the
+// type the operator gives them
+func operatorTypes(operator int, names ...string) ast.SystemFunctionTypes {
+	return func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) != len(names) {
+			return nil, ast.TypeNone, false
+		}
+
+		params := make([]ast.Parameter, len(names))
+		for i, t := range argTypes {
+			if !ast.IsNumericType(t) {
+				return nil, ast.TypeNone, false
+			}
+			params[i] = ast.Parameter{Name: names[i], DataType: t}
+		}
+
+		if len(argTypes) == 1 {
+			return params, argTypes[0], true
+		}
+		result, ok := ast.BinaryResultType(operator, argTypes[0], argTypes[1])
+		return params, result, ok
+	}
+}
+
+// floatFunction wraps a function of one float
+func floatFunction(f func(float64) float64) ast.SystemFunctionCall {
+	return func(args []interface{}) interface{} {
+		return f(args[0].(ast.NumberValue).GetFloatValue())
+	}
+}
+
+// logFunction wraps a logarithm, which is only
+// defined for positive numbers
+func logFunction(f func(float64) float64) ast.SystemFunctionCall {
+	return func(args []interface{}) interface{} {
+		n := args[0].(ast.NumberValue)
+		if n.GetFloatValue() <= 0 {
+			return fmt.Errorf("Logarithm of non-positive number %s", n.ToString())
+		}
+		return f(n.GetFloatValue())
+	}
+}
+
+// roundingFunction wraps a function that rounds a float
+// to a whole number, returning the result as an int.  A
+// float too large for an int is an error, as with int()
+func roundingFunction(f func(float64) float64) ast.SystemFunctionCall {
+	return func(args []interface{}) interface{} {
+		n := args[0].(ast.NumberValue)
+		if n.GetNumberType() == ast.IntType {
+			return n
+		}
+
+		result, err := ast.NewFloatNumber(f(n.GetFloatValue())).ToInt()
+		if err != nil {
+			return err
+		}
+		return result
+	}
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// initRangeFunctions loads range(), which gives the
+// numbers for a for-in loop to count through
+func initRangeFunctions() {
+	// range counts from start up to, but not including,
+	// end.  The step is 1 unless it is given, and a
+	// negative step counts down instead
+	rangeFunction := NewSystemFunction("range", intParams("start", "end", "step"), ast.TypeRange,
+		func(args []interface{}) interface{} {
+			start := args[0].(ast.NumberValue).GetIntValue()
+			end := args[1].(ast.NumberValue).GetIntValue()
+			step := int64(1)
+			if len(args) > 2 {
+				step = args[2].(ast.NumberValue).GetIntValue()
+			}
+
+			if step == 0 {
+				return fmt.Errorf("Range step cannot be 0")
+			}
+			return ast.NewRange(start, end, step)
+		})
+	rangeFunction.ResolveTypes = func(types *ast.TypeTable, argTypes []int) ([]ast.Parameter, int, bool) {
+		if len(argTypes) < 2 || len(argTypes) > 3 {
+			return nil, ast.TypeNone, false
+		}
+		return intParams("start", "end", "step")[:len(argTypes)], ast.TypeRange, true
+	}
+}
+
+// intParams creates a list of int parameters
+func intParams(names ...string) []ast.Parameter {
+	result := make([]ast.Parameter, len(names))
+	for i, n := range names {
+		result[i] = ast.Parameter{Name: n, DataType: ast.TypeInt}
+	}
+	return result
+}
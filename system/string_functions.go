@@ -0,0 +1,101 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hculpan/kablang/ast"
+)
+
+// maxStringLength is the most bytes a string built by
+// repeat() may hold, so that a large count is an error
+// rather than running out of memory
+const maxStringLength = 1 << 30
+
+// initStringFunctions loads the string functions.  Lengths
+// and positions count characters rather than bytes, and
+// positions start at 0.  len() is with the list functions.
+func initStringFunctions() {
+	NewSystemFunction("upper", strParams("s"), ast.TypeString, func(args []interface{}) interface{} {
+		return strings.ToUpper(args[0].(string))
+	})
+
+	NewSystemFunction("lower", strParams("s"), ast.TypeString, func(args []interface{}) interface{} {
+		return strings.ToLower(args[0].(string))
+	})
+
+	NewSystemFunction("trim", strParams("s"), ast.TypeString, func(args []interface{}) interface{} {
+		return strings.TrimSpace(args[0].(string))
+	})
+
+	NewSystemFunction("substr", append(strParams("s"), intParams("start", "length")...), ast.TypeString,
+		func(args []interface{}) interface{} {
+			s := []rune(args[0].(string))
+			start := args[1].(ast.NumberValue).GetIntValue()
+			length := args[2].(ast.NumberValue).GetIntValue()
+			if start < 0 || start > int64(len(s)) {
+				return fmt.Errorf("Start %d out of range for string of length %d", start, len(s))
+			}
+			if length < 0 || length > int64(len(s))-start {
+				return fmt.Errorf("Length %d out of range for string of length %d starting at %d", length, len(s), start)
+			}
+			return string(s[start : start+length])
+		})
+
+	NewSystemFunction("indexOf", strParams("s", "substr"), ast.TypeInt, func(args []interface{}) interface{} {
+		s, sub := args[0].(string), args[1].(string)
+		i := strings.Index(s, sub)
+		if i < 0 {
+			return i
+		}
+		return len([]rune(s[:i]))
+	})
+
+	NewSystemFunction("contains", strParams("s", "substr"), ast.TypeBool, func(args []interface{}) interface{} {
+		return strings.Contains(args[0].(string), args[1].(string))
+	})
+
+	NewSystemFunction("startsWith", strParams("s", "prefix"), ast.TypeBool, func(args []interface{}) interface{} {
+		return strings.HasPrefix(args[0].(string), args[1].(string))
+	})
+
+	NewSystemFunction("endsWith", strParams("s", "suffix"), ast.TypeBool, func(args []interface{}) interface{} {
+		return strings.HasSuffix(args[0].(string), args[1].(string))
+	})
+
+	NewSystemFunction("replace", strParams("s", "old", "new"), ast.TypeString, func(args []interface{}) interface{} {
+		return strings.ReplaceAll(args[0].(string), args[1].(string), args[2].(string))
+	})
+
+	NewSystemFunction("repeat", append(strParams("s"), intParams("count")...), ast.TypeString,
+		func(args []interface{}) interface{} {
+			s, count := args[0].(string), args[1].(ast.NumberValue).GetIntValue()
+			if count < 0 {
+				return fmt.Errorf("Negative repeat count %d", count)
+			}
+			if len(s) > 0 && count > maxStringLength/int64(len(s)) {
+				return fmt.Errorf("Repeat count %d too large for string of length %d", count, len([]rune(s)))
+			}
+			return strings.Repeat(s, int(count))
+		})
+
+	// split returns a new list of the parts of s between
+	// each sep.  An empty sep splits s into characters
+	NewSystemFunction("split", strParams("s", "sep"), ast.TypeStringList, func(args []interface{}) interface{} {
+		parts := strings.Split(args[0].(string), args[1].(string))
+		elements := make([]interface{}, len(parts))
+		for i, p := range parts {
+			elements[i] = ast.NewString(p)
+		}
+		return ast.NewList(ast.TypeStringList, elements)
+	})
+}
+
+// strParams creates a list of string parameters
+func strParams(names ...string) []ast.Parameter {
+	result := make([]ast.Parameter, len(names))
+	for i, n := range names {
+		result[i] = ast.Parameter{Name: n, DataType: ast.TypeString}
+	}
+	return result
+}
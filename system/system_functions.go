@@ -1,3 +1,19 @@
+// Package system contains the functions built into
+// the Kab language.
+//
+// Built-in functions receive and return Go values:
+// a number is passed as an ast.NumberValue, a string
+// as a string, a bool as a bool, and a list or a map
+// as an *ast.List or an *ast.Map.  A function may
+// return any of these (or a Go int or float), nil if
+// it has no return type, or an error to stop the
+// program with a runtime error.
+//
+// Host applications can add their own functions by
+// calling NewSystemFunction before parsing.  Each program
+// has its own list and map types, other than []string, so
+// a function that takes or returns them should set
+// ResolveTypes, which is given the program's TypeTable.
 package system
 
 import (
@@ -7,9 +23,14 @@ import (
 )
 
 // BuiltInFunctions contains a list of all built-in functions
-var BuiltInFunctions map[string]*ast.Function
+var BuiltInFunctions map[string]*ast.Function = map[string]*ast.Function{}
 
-// NewSystemFunction creates a new built-in function
+func init() {
+	InitSystemFunctions()
+}
+
+// NewSystemFunction creates a new built-in function,
+// replacing any existing function with the same name
 func NewSystemFunction(
 	name string,
 	params []ast.Parameter,
@@ -25,10 +46,26 @@ func NewSystemFunction(
 	return result
 }
 
-// InitSystemFunctions loads all the system functions
+// GetSystemFunction returns the built-in
+// function with the given name
+func GetSystemFunction(name string) (*ast.Function, bool) {
+	result, exists := BuiltInFunctions[name]
+	return result, exists
+}
+
+// InitSystemFunctions loads all the system functions.
+// It is called automatically, but can be called again
+// to restore any that have been replaced.
 func InitSystemFunctions() {
-	NewSystemFunction("PrintHello", []ast.Parameter{}, ast.TypeString, func([]interface{}) interface{} {
+	NewSystemFunction("PrintHello", []ast.Parameter{}, ast.TypeNone, func([]interface{}) interface{} {
 		fmt.Println("Hello")
-		return 0
+		return nil
 	})
+
+	initMathFunctions()
+	initStringFunctions()
+	initListFunctions()
+	initMapFunctions()
+	initRangeFunctions()
+	initConversionFunctions()
 }